@@ -88,7 +88,7 @@ var (
 		},
 		"job-columns": {
 			cfgFileEnvVar: "wander_job_columns",
-			description:   `Columns to display for Jobs view - can reference Meta keys`,
+			description:   `Columns to display for Jobs view - can reference Meta keys, or Children and Next Launch for parent jobs`,
 			defaultString: "Job,Type,Namespace,Status,Count,Submitted,Since Submit",
 		},
 		"all-tasks-columns": {
@@ -101,6 +101,16 @@ var (
 			description:   `Columns to display for Tasks for Job view`,
 			defaultString: "Node ID,Alloc ID,Task Group,Alloc Name,Task Name,State,Started,Finished,Uptime",
 		},
+		"node-columns": {
+			cfgFileEnvVar: "wander_node_columns",
+			description:   `Columns to display for Nodes view`,
+			defaultString: "Name,Datacenter,Class,Status,Eligibility,Drain,Version,CPU,Memory",
+		},
+		"tasks-for-node-columns": {
+			cfgFileEnvVar: "wander_tasks_for_node_columns",
+			description:   `Columns to display for Tasks for Node view`,
+			defaultString: "Job,Alloc ID,Task Group,Alloc Name,Task Name,State,Started,Finished,Uptime",
+		},
 		"log-offset": {
 			cliShort:      "o",
 			cfgFileEnvVar: "wander_log_offset",
@@ -108,6 +118,18 @@ var (
 			isInt:         true,
 			defaultIfInt:  1000000,
 		},
+		"log-max-lines": {
+			cfgFileEnvVar: "wander_log_max_lines",
+			description:   `Most lines of followed logs kept, evicting the oldest. 0 for no limit`,
+			isInt:         true,
+			defaultIfInt:  100000,
+		},
+		"log-receive-times": {
+			cfgFileEnvVar: "wander_log_receive_times",
+			description:   `Stamp followed log lines with the time they're received`,
+			isBool:        true,
+			defaultIfBool: false,
+		},
 		"log-tail": {
 			cliShort:      "f",
 			cfgFileEnvVar: "wander_log_tail",
@@ -142,9 +164,30 @@ var (
 			description:   `jq query for allocation-specific events. "." for entire JSON`,
 			defaultString: constants.DefaultAllocEventJQQuery,
 		},
+		"log-json-query": {
+			cfgFileEnvVar: "wander_log_json_query",
+			description:   `jq query turning JSON log lines into an object of columns, shown when toggling json fields in logs`,
+			defaultString: constants.DefaultLogJSONQuery,
+		},
+		"log-json-queries": {
+			cfgFileEnvVar: "wander_log_json_queries",
+		},
+		"log-levels": {
+			cfgFileEnvVar: "wander_log_levels",
+		},
 		"logo-color": {
 			cfgFileEnvVar: "wander_logo_color",
 		},
+		"profile-color": {
+			cfgFileEnvVar: "wander_profile_color",
+		},
+		"profiles": {
+			cfgFileEnvVar: "wander_profiles",
+		},
+		"profile": {
+			cfgFileEnvVar: "wander_profile",
+			description:   `Profile from wander_profiles in the config file to start with`,
+		},
 		"compact-header": {
 			cfgFileEnvVar: "wander_compact_header",
 			description:   `Start with compact header`,
@@ -226,18 +269,24 @@ func init() {
 		"job-columns",
 		"all-tasks-columns",
 		"tasks-for-job-columns",
+		"node-columns",
+		"tasks-for-node-columns",
 		"log-offset",
+		"log-max-lines",
+		"log-receive-times",
 		"log-tail",
 		"copy-save-path",
 		"event-topics",
 		"event-namespace",
 		"event-jq-query",
 		"alloc-event-jq-query",
+		"log-json-query",
 		"compact-header",
 		"start-all-tasks",
 		"compact-tables",
 		"start-filtering",
 		"filter-with-context",
+		"profile",
 	} {
 		c := rootNameToArg[cliLong]
 		if c.isBool {
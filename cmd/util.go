@@ -15,6 +15,8 @@ import (
 	"github.com/spf13/viper"
 	"log"
 	"os"
+	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -53,6 +55,10 @@ func retrieveLogoColor() string {
 	return viper.GetString(rootNameToArg["logo-color"].cfgFileEnvVar)
 }
 
+func retrieveProfileColor() string {
+	return viper.GetString(rootNameToArg["profile-color"].cfgFileEnvVar)
+}
+
 func retrieveAddress(cmd *cobra.Command) string {
 	return cmd.Flags().Lookup("addr").Value.String()
 }
@@ -189,6 +195,76 @@ func retrieveAllocEventJQQuery(cmd *cobra.Command) *gojq.Code {
 	return code
 }
 
+func retrieveLogJSONQuery(cmd *cobra.Command) *gojq.Code {
+	query := cmd.Flags().Lookup("log-json-query").Value.String()
+	return compileLogJSONQuery(query, "log json query")
+}
+
+// retrieveJobLogJSONQueries reads the jq queries for the JSON logs of specific jobs in the config file, by lowercase
+// job ID as the config file keys are case-insensitive
+func retrieveJobLogJSONQueries() map[string]*gojq.Code {
+	queries := make(map[string]*gojq.Code)
+	for jobID, query := range jobConfigSection("log-json-queries") {
+		queryString, ok := query.(string)
+		if !ok {
+			fmt.Printf("Error reading log json query for job %s: expected a jq query\n", jobID)
+			os.Exit(1)
+		}
+		queries[jobID] = compileLogJSONQuery(queryString, fmt.Sprintf("log json query for job %s", jobID))
+	}
+	return queries
+}
+
+// retrieveJobLogLevelPatterns reads the regexes detecting the log levels of specific jobs in the config file, by
+// lowercase job ID as the config file keys are case-insensitive
+func retrieveJobLogLevelPatterns() map[string]nomad.LogLevelPatterns {
+	jobPatterns := make(map[string]nomad.LogLevelPatterns)
+	for jobID, levels := range jobConfigSection("log-levels") {
+		levelsMap, ok := levels.(map[string]interface{})
+		if !ok {
+			fmt.Printf("Error reading log levels for job %s: expected a regex for each level\n", jobID)
+			os.Exit(1)
+		}
+		patterns := make(nomad.LogLevelPatterns)
+		for levelName, pattern := range levelsMap {
+			level, err := nomad.LogLevelFromString(levelName)
+			if err != nil {
+				fmt.Printf("Error reading log levels for job %s: %s\n", jobID, err.Error())
+				os.Exit(1)
+			}
+			compiled, err := regexp.Compile(fmt.Sprint(pattern))
+			if err != nil {
+				fmt.Printf("Error compiling %s log level regex for job %s: %s\n", levelName, jobID, err.Error())
+				os.Exit(1)
+			}
+			patterns[level] = compiled
+		}
+		jobPatterns[jobID] = patterns
+	}
+	return jobPatterns
+}
+
+// jobConfigSection reads a section of the config file by job ID as it is. Job IDs may have dots, which viper splits the
+// keys it looks up on, so the job IDs are never looked up as keys
+func jobConfigSection(cliLong string) map[string]interface{} {
+	section, _ := viper.Get(rootNameToArg[cliLong].cfgFileEnvVar).(map[string]interface{})
+	return section
+}
+
+func compileLogJSONQuery(query, name string) *gojq.Code {
+	parsed, err := gojq.Parse(query)
+	if err != nil {
+		fmt.Printf("Error parsing %s: %s\n", name, err.Error())
+		os.Exit(1)
+	}
+	code, err := gojq.Compile(parsed)
+	if err != nil {
+		fmt.Printf("Error compiling %s: %s\n", name, err.Error())
+		os.Exit(1)
+	}
+	return code
+}
+
 func retrieveUpdateSeconds(cmd *cobra.Command) int {
 	updateSecondsString := cmd.Flags().Lookup("update").Value.String()
 	updateSeconds, err := strconv.Atoi(updateSecondsString)
@@ -229,6 +305,26 @@ func retrieveJobTaskColumns(cmd *cobra.Command) []string {
 	return trimmed
 }
 
+func retrieveNodeColumns(cmd *cobra.Command) []string {
+	columnsString := cmd.Flags().Lookup("node-columns").Value.String()
+	split := strings.Split(columnsString, ",")
+	var trimmed []string
+	for _, s := range split {
+		trimmed = append(trimmed, strings.TrimSpace(s))
+	}
+	return trimmed
+}
+
+func retrieveNodeTaskColumns(cmd *cobra.Command) []string {
+	columnsString := cmd.Flags().Lookup("tasks-for-node-columns").Value.String()
+	split := strings.Split(columnsString, ",")
+	var trimmed []string
+	for _, s := range split {
+		trimmed = append(trimmed, strings.TrimSpace(s))
+	}
+	return trimmed
+}
+
 func retrieveLogOffset(cmd *cobra.Command) int {
 	logOffsetString := cmd.Flags().Lookup("log-offset").Value.String()
 	logOffset, err := strconv.Atoi(logOffsetString)
@@ -239,11 +335,30 @@ func retrieveLogOffset(cmd *cobra.Command) int {
 	return logOffset
 }
 
+func retrieveLogMaxLines(cmd *cobra.Command) int {
+	logMaxLinesString := cmd.Flags().Lookup("log-max-lines").Value.String()
+	logMaxLines, err := strconv.Atoi(logMaxLinesString)
+	if err != nil {
+		fmt.Println(fmt.Errorf("log max lines %s cannot be converted to an integer", logMaxLinesString))
+		os.Exit(1)
+	}
+	if logMaxLines < 0 {
+		fmt.Println(fmt.Errorf("log max lines %d cannot be negative, use 0 for no limit", logMaxLines))
+		os.Exit(1)
+	}
+	return logMaxLines
+}
+
 func retrieveLogTail(cmd *cobra.Command) bool {
 	v := cmd.Flags().Lookup("log-tail").Value.String()
 	return trueIfTrue(v)
 }
 
+func retrieveLogReceiveTimes(cmd *cobra.Command) bool {
+	v := cmd.Flags().Lookup("log-receive-times").Value.String()
+	return trueIfTrue(v)
+}
+
 func retrieveStartCompact(cmd *cobra.Command) bool {
 	v := cmd.Flags().Lookup("compact-header").Value.String()
 	return trueIfTrue(v)
@@ -269,6 +384,113 @@ func retrieveFilterWithContext(cmd *cobra.Command) bool {
 	return trueIfTrue(v)
 }
 
+func retrieveProfileName(cmd *cobra.Command) string {
+	return cmd.Flags().Lookup("profile").Value.String()
+}
+
+// retrieveProfiles reads the named profiles in the config file, which override the settings of the default profile. A
+// profile named like the default profile replaces it
+func retrieveProfiles(defaultProfile app.Profile) []app.Profile {
+	profilesKey := rootNameToArg["profiles"].cfgFileEnvVar
+	var names []string
+	for name := range viper.GetStringMap(profilesKey) {
+		names = append(names, name)
+	}
+	if len(names) == 0 {
+		return nil
+	}
+	sort.Strings(names)
+
+	profiles := []app.Profile{defaultProfile}
+	for _, name := range names {
+		sub := viper.Sub(profilesKey + "." + name)
+		if sub == nil {
+			continue
+		}
+		getString := func(cliLong, fallback string) string {
+			if k := rootNameToArg[cliLong].cfgFileEnvVar; sub.IsSet(k) {
+				return sub.GetString(k)
+			}
+			return fallback
+		}
+		getColumns := func(cliLong string, fallback []string) []string {
+			if k := rootNameToArg[cliLong].cfgFileEnvVar; sub.IsSet(k) {
+				var trimmed []string
+				for _, s := range strings.Split(sub.GetString(k), ",") {
+					trimmed = append(trimmed, strings.TrimSpace(s))
+				}
+				return trimmed
+			}
+			return fallback
+		}
+
+		namespace := getString("namespace", defaultProfile.Namespace)
+		// events follow the namespace of the profile, as when switching namespaces, unless set separately
+		eventNamespace := defaultProfile.EventNamespace
+		if namespace != defaultProfile.Namespace {
+			eventNamespace = namespace
+		}
+		color := ""
+		if name == defaultProfile.Name {
+			color = defaultProfile.Color
+		}
+		profile := app.Profile{
+			Name:           name,
+			Color:          getString("profile-color", color),
+			URL:            getString("addr", defaultProfile.URL),
+			Token:          getString("token", defaultProfile.Token),
+			Region:         getString("region", defaultProfile.Region),
+			Namespace:      namespace,
+			EventNamespace: getString("event-namespace", eventNamespace),
+			HTTPAuth:       getString("http-auth", defaultProfile.HTTPAuth),
+			TLS: app.TLSConfig{
+				CACert:     getString("cacert", defaultProfile.TLS.CACert),
+				CAPath:     getString("capath", defaultProfile.TLS.CAPath),
+				ClientCert: getString("client-cert", defaultProfile.TLS.ClientCert),
+				ClientKey:  getString("client-key", defaultProfile.TLS.ClientKey),
+				ServerName: getString("tls-server-name", defaultProfile.TLS.ServerName),
+				SkipVerify: trueIfTrue(getString("skip-verify", strconv.FormatBool(defaultProfile.TLS.SkipVerify))),
+			},
+			JobColumns:      getColumns("job-columns", defaultProfile.JobColumns),
+			AllTaskColumns:  getColumns("all-tasks-columns", defaultProfile.AllTaskColumns),
+			JobTaskColumns:  getColumns("tasks-for-job-columns", defaultProfile.JobTaskColumns),
+			NodeColumns:     getColumns("node-columns", defaultProfile.NodeColumns),
+			NodeTaskColumns: getColumns("tasks-for-node-columns", defaultProfile.NodeTaskColumns),
+		}
+		if err := validateToken(profile.Token); err != nil {
+			fmt.Printf("profile %s: %s\n", name, err.Error())
+			os.Exit(1)
+		}
+		if name == defaultProfile.Name {
+			profiles[0] = profile
+			continue
+		}
+		profiles = append(profiles, profile)
+	}
+	return profiles
+}
+
+func retrieveStartProfile(cmd *cobra.Command, profiles []app.Profile, defaultProfile app.Profile) app.Profile {
+	name := retrieveProfileName(cmd)
+	if name == "" {
+		if len(profiles) == 0 {
+			// no profiles configured, so there's no need to show the profile name
+			defaultProfile.Name = ""
+			return defaultProfile
+		}
+		// the default profile, as overridden in the config file if it is
+		return profiles[0]
+	}
+	for _, profile := range profiles {
+		if profile.Name == strings.ToLower(name) {
+			return profile
+		}
+	}
+	fmt.Printf("profile %s not found in %s\n", name, rootNameToArg["profiles"].cfgFileEnvVar)
+	os.Exit(1)
+	return app.Profile{}
+}
+
 // customLoggingMiddleware provides basic connection logging. Connects are logged with the
 // remote address, invoked command, TERM setting, window dimensions and if the
 // auth was public key based. Disconnect will log the remote address and
@@ -306,15 +528,22 @@ func setup(cmd *cobra.Command, overrideToken string) (app.Model, []tea.ProgramOp
 	skipVerify := retrieveSkipVerify(cmd)
 	logOffset := retrieveLogOffset(cmd)
 	logTail := retrieveLogTail(cmd)
+	logMaxLines := retrieveLogMaxLines(cmd)
+	logReceiveTimes := retrieveLogReceiveTimes(cmd)
 	copySavePath := retrieveCopySavePath(cmd)
 	eventTopics := retrieveEventTopics(cmd)
 	eventNamespace := retrieveEventNamespace(cmd)
 	eventJQQuery := retrieveEventJQQuery(cmd)
 	allocEventJQQuery := retrieveAllocEventJQQuery(cmd)
+	logJSONQuery := retrieveLogJSONQuery(cmd)
+	jobLogJSONQueries := retrieveJobLogJSONQueries()
+	jobLogLevelPatterns := retrieveJobLogLevelPatterns()
 	updateSeconds := retrieveUpdateSeconds(cmd)
 	jobColumns := retrieveJobColumns(cmd)
 	allTaskColumns := retrieveAllTaskColumns(cmd)
 	jobTaskColumns := retrieveJobTaskColumns(cmd)
+	nodeColumns := retrieveNodeColumns(cmd)
+	nodeTaskColumns := retrieveNodeTaskColumns(cmd)
 	logoColor := retrieveLogoColor()
 	startCompact := retrieveStartCompact(cmd)
 	startAllTasksView := retrieveStartAllTasksView(cmd)
@@ -322,13 +551,15 @@ func setup(cmd *cobra.Command, overrideToken string) (app.Model, []tea.ProgramOp
 	startFiltering := retrieveStartFiltering(cmd)
 	filterWithContext := retrieveFilterWithContext(cmd)
 
-	initialModel := app.InitialModel(app.Config{
-		Version:   getVersion(),
-		URL:       nomadAddr,
-		Token:     nomadToken,
-		Region:    region,
-		Namespace: namespace,
-		HTTPAuth:  httpAuth,
+	defaultProfile := app.Profile{
+		Name:           "default",
+		Color:          retrieveProfileColor(),
+		URL:            nomadAddr,
+		Token:          nomadToken,
+		Region:         region,
+		Namespace:      namespace,
+		EventNamespace: eventNamespace,
+		HTTPAuth:       httpAuth,
 		TLS: app.TLSConfig{
 			CACert:     cacert,
 			CAPath:     capath,
@@ -337,27 +568,46 @@ func setup(cmd *cobra.Command, overrideToken string) (app.Model, []tea.ProgramOp
 			ServerName: tlsServerName,
 			SkipVerify: skipVerify,
 		},
+		JobColumns:      jobColumns,
+		AllTaskColumns:  allTaskColumns,
+		JobTaskColumns:  jobTaskColumns,
+		NodeColumns:     nodeColumns,
+		NodeTaskColumns: nodeTaskColumns,
+	}
+	profiles := retrieveProfiles(defaultProfile)
+	if overrideToken != "" {
+		// a token passed in over ssh applies to every profile, not just the default one
+		for i := range profiles {
+			profiles[i].Token = overrideToken
+		}
+	}
+	profile := retrieveStartProfile(cmd, profiles, defaultProfile)
+
+	initialModel := app.InitialModel(app.Config{
+		Version: getVersion(),
 		Log: app.LogConfig{
-			Offset: logOffset,
-			Tail:   logTail,
+			Offset:           logOffset,
+			Tail:             logTail,
+			MaxLines:         logMaxLines,
+			ReceiveTimes:     logReceiveTimes,
+			JSONQuery:        logJSONQuery,
+			JobJSONQueries:   jobLogJSONQueries,
+			JobLevelPatterns: jobLogLevelPatterns,
 		},
 		CopySavePath: copySavePath,
 		Event: app.EventConfig{
 			Topics:       eventTopics,
-			Namespace:    eventNamespace,
 			JQQuery:      eventJQQuery,
 			AllocJQQuery: allocEventJQQuery,
 		},
 		UpdateSeconds:     time.Second * time.Duration(updateSeconds),
-		JobColumns:        jobColumns,
-		AllTaskColumns:    allTaskColumns,
-		JobTaskColumns:    jobTaskColumns,
 		LogoColor:         logoColor,
 		StartCompact:      startCompact,
 		StartAllTasksView: startAllTasksView,
 		CompactTables:     compactTables,
 		StartFiltering:    startFiltering,
 		FilterWithContext: filterWithContext,
-	})
+		Profiles:          profiles,
+	}.WithProfile(profile))
 	return initialModel, []tea.ProgramOption{tea.WithAltScreen()}
 }
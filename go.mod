@@ -3,6 +3,7 @@ module github.com/robinovitch61/wander
 go 1.21
 
 require (
+	github.com/anmitsu/go-shlex v0.0.0-20200514113438-38f4b401e2be
 	github.com/atotto/clipboard v0.1.4
 	github.com/carlmjohnson/versioninfo v0.22.4
 	github.com/charmbracelet/bubbles v0.16.1
@@ -20,7 +21,6 @@ require (
 )
 
 require (
-	github.com/anmitsu/go-shlex v0.0.0-20200514113438-38f4b401e2be // indirect
 	github.com/aymanbagabas/go-osc52/v2 v2.0.1 // indirect
 	github.com/caarlos0/sshmarshal v0.1.0 // indirect
 	github.com/charmbracelet/keygen v0.4.2 // indirect
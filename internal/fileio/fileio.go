@@ -1,6 +1,7 @@
 package fileio
 
 import (
+	"compress/gzip"
 	"fmt"
 	"github.com/robinovitch61/wander/internal/tui/formatter"
 	"os"
@@ -11,6 +12,72 @@ import (
 )
 
 func SaveToFile(saveDialogValue string, fileContent []string) (string, error) {
+	f, pathWithFileName, err := CreateFile(saveDialogValue)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	for _, line := range fileContent {
+		_, writeErr := f.WriteString(line)
+		if writeErr != nil {
+			return "", writeErr
+		}
+	}
+
+	return pathWithFileName, nil
+}
+
+// Writer writes to a file named as in SaveToFile, compressing it with gzip if the name ends in .gz
+type Writer struct {
+	Path string
+	file *os.File
+	gz   *gzip.Writer
+	// flushEachWrite makes what's written readable before the Writer is closed, at the cost of worse compression
+	flushEachWrite bool
+}
+
+func NewWriter(saveDialogValue string, flushEachWrite bool) (*Writer, error) {
+	f, pathWithFileName, err := CreateFile(saveDialogValue)
+	if err != nil {
+		return nil, err
+	}
+	w := &Writer{Path: pathWithFileName, file: f, flushEachWrite: flushEachWrite}
+	if strings.HasSuffix(pathWithFileName, ".gz") {
+		w.gz = gzip.NewWriter(f)
+	}
+	return w, nil
+}
+
+func (w *Writer) Write(p []byte) (int, error) {
+	if w.gz == nil {
+		return w.file.Write(p)
+	}
+	n, err := w.gz.Write(p)
+	if err != nil || !w.flushEachWrite {
+		return n, err
+	}
+	return n, w.gz.Flush()
+}
+
+func (w *Writer) Close() error {
+	if w.gz != nil {
+		if err := w.gz.Close(); err != nil {
+			w.file.Close()
+			return err
+		}
+	}
+	return w.file.Close()
+}
+
+// Discard closes the file and removes it, e.g. when it couldn't be written in full
+func (w *Writer) Discard() error {
+	w.Close()
+	return os.Remove(w.Path)
+}
+
+// CreateFile creates the file to save to, adding the current time to its name if it already exists
+func CreateFile(saveDialogValue string) (*os.File, string, error) {
 	var path, fileName string
 
 	if saveDialogValue == "" {
@@ -20,7 +87,7 @@ func SaveToFile(saveDialogValue string, fileContent []string) (string, error) {
 		if strings.Contains(saveDialogValue, "~") {
 			currUser, userErr := user.Current()
 			if userErr != nil {
-				return "", userErr
+				return nil, "", userErr
 			}
 			saveDialogValue = strings.ReplaceAll(saveDialogValue, "~", currUser.HomeDir)
 		}
@@ -36,17 +103,17 @@ func SaveToFile(saveDialogValue string, fileContent []string) (string, error) {
 
 	cleanPath, cleanPathErr := filepath.Abs(path)
 	if cleanPathErr != nil {
-		return "", cleanPathErr
+		return nil, "", cleanPathErr
 	}
 
 	if exists, pathExistsErr := fileOrDirectoryExists(cleanPath); pathExistsErr == nil {
 		if !exists {
 			if mkdirErr := os.MkdirAll(cleanPath, 0755); mkdirErr != nil {
-				return "", mkdirErr
+				return nil, "", mkdirErr
 			}
 		}
 	} else {
-		return "", pathExistsErr
+		return nil, "", pathExistsErr
 	}
 
 	pathWithFileName := fmt.Sprintf("%s/%s", cleanPath, fileName)
@@ -62,23 +129,14 @@ func SaveToFile(saveDialogValue string, fileContent []string) (string, error) {
 			}
 		}
 	} else {
-		return "", fileExistsErr
+		return nil, "", fileExistsErr
 	}
 
 	f, createErr := os.Create(pathWithFileName)
 	if createErr != nil {
-		return "", createErr
+		return nil, "", createErr
 	}
-	defer f.Close()
-
-	for _, line := range fileContent {
-		_, writeErr := f.WriteString(line)
-		if writeErr != nil {
-			return "", writeErr
-		}
-	}
-
-	return pathWithFileName, nil
+	return f, pathWithFileName, nil
 }
 
 func fileOrDirectoryExists(path string) (bool, error) {
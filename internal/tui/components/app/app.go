@@ -4,10 +4,12 @@ import (
 	"fmt"
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
 	"github.com/gorilla/websocket"
 	"github.com/hashicorp/nomad/api"
 	"github.com/itchyny/gojq"
 	"github.com/robinovitch61/wander/internal/dev"
+	"github.com/robinovitch61/wander/internal/fileio"
 	"github.com/robinovitch61/wander/internal/tui/components/header"
 	"github.com/robinovitch61/wander/internal/tui/components/page"
 	"github.com/robinovitch61/wander/internal/tui/constants"
@@ -17,10 +19,23 @@ import (
 	"github.com/robinovitch61/wander/internal/tui/nomad"
 	"github.com/robinovitch61/wander/internal/tui/style"
 	"os"
+	"path"
+	"strconv"
 	"strings"
 	"time"
 )
 
+// logsInput is what the input requested on the logs pages is for
+type logsInput int8
+
+const (
+	searchLogsInput logsInput = iota
+	jumpToTimeInput
+	logsSinceInput
+	recordLogsInput
+	downloadLogsInput
+)
+
 type TLSConfig struct {
 	CACert, CAPath, ClientCert, ClientKey, ServerName string
 	SkipVerify                                        bool
@@ -34,8 +49,41 @@ type EventConfig struct {
 }
 
 type LogConfig struct {
-	Offset int
-	Tail   bool
+	Offset         int
+	Tail           bool
+	MaxLines       int
+	ReceiveTimes   bool
+	JSONQuery      *gojq.Code
+	JobJSONQueries map[string]*gojq.Code
+	// JobLevelPatterns override the patterns that detect log levels for specific jobs, by lowercase job ID
+	JobLevelPatterns map[string]nomad.LogLevelPatterns
+}
+
+// jsonQuery is the jq query for the JSON logs of a job, which may be configured per job
+func (c LogConfig) jsonQuery(jobID string) *gojq.Code {
+	if query, exists := c.JobJSONQueries[strings.ToLower(jobID)]; exists {
+		return query
+	}
+	return c.JSONQuery
+}
+
+// levelPatterns detect the levels of the logs of a job
+func (c LogConfig) levelPatterns(jobID string) nomad.LogLevelPatterns {
+	return c.JobLevelPatterns[strings.ToLower(jobID)].WithDefaults()
+}
+
+// Profile is a named cluster connection that can be switched to while running
+type Profile struct {
+	Name, Color                   string
+	URL, Token, Region, Namespace string
+	EventNamespace                string
+	HTTPAuth                      string
+	TLS                           TLSConfig
+	JobColumns                    []string
+	AllTaskColumns                []string
+	JobTaskColumns                []string
+	NodeColumns                   []string
+	NodeTaskColumns               []string
 }
 
 type Config struct {
@@ -50,12 +98,16 @@ type Config struct {
 	JobColumns                    []string
 	AllTaskColumns                []string
 	JobTaskColumns                []string
+	NodeColumns                   []string
+	NodeTaskColumns               []string
 	LogoColor                     string
 	StartCompact                  bool
 	StartAllTasksView             bool
 	CompactTables                 bool
 	StartFiltering                bool
 	FilterWithContext             bool
+	Profiles                      []Profile
+	Profile                       Profile
 }
 
 type Model struct {
@@ -67,13 +119,39 @@ type Model struct {
 	currentPage nomad.Page
 	pageModels  map[nomad.Page]*page.Model
 
-	inJobsMode   bool
-	jobID        string
-	jobNamespace string
-	alloc        api.Allocation
-	taskName     string
-	logline      string
-	logType      nomad.LogType
+	mode          nomad.Mode
+	expandedJobs  map[string]bool
+	allRegions    bool
+	jobID         string
+	jobNamespace  string
+	nodeID        string
+	nodeName      string
+	evalID        string
+	evalNamespace string
+
+	scaleTaskGroup string
+	launchInfo     nomad.JobLaunchInfoMsg
+
+	variablePath         string
+	variableNamespace    string
+	revealVariableValues bool
+
+	serviceName      string
+	serviceNamespace string
+
+	volumeID        string
+	volumeNamespace string
+
+	markedJobVersion               string
+	diffFromVersion, diffToVersion uint64
+
+	alloc    api.Allocation
+	taskName string
+	logline  string
+	logType  nomad.LogType
+
+	allocFilePath       string
+	allocFileToDownload string
 
 	updateID int
 
@@ -83,6 +161,29 @@ type Model struct {
 
 	logsStream      nomad.LogsStream
 	lastLogFinished bool
+	showJSONLogs    bool
+	jsonLogs        nomad.JSONLogs
+	logLevels       nomad.LogLevels
+	minLogLevel     nomad.LogLevel
+	logReceiveTimes bool
+	logTimes        nomad.LogTimes
+	logsSince       time.Time
+	logsInput       logsInput
+	logRecording    *fileio.Writer
+
+	// followed logs are buffered while paused
+	logsPaused         bool
+	pausedLogRows      []page.Row
+	pausedLineCount    int
+	pausedRerenderJSON bool
+
+	logSearchQuery string
+	logSearch      nomad.LogSearch
+
+	jobLogsTaskGroup   string
+	jobLogsStream      nomad.JobLogsStream
+	jobLogLines        []nomad.JobLogLine
+	hiddenJobLogAllocs map[string]bool
 
 	execWebSocket       *websocket.Conn
 	execPty             *os.File
@@ -103,21 +204,35 @@ func getFirstPage(c Config) nomad.Page {
 	return firstPage
 }
 
+func getFirstMode(c Config) nomad.Mode {
+	if c.StartAllTasksView {
+		return nomad.AllTasksMode
+	}
+	return nomad.JobsMode
+}
+
 func InitialModel(c Config) Model {
+	keymap.KeyMap.Profiles.SetEnabled(len(c.Profiles) > 0)
 	firstPage := getFirstPage(c)
 	initialHeader := header.New(
 		constants.LogoString,
 		c.LogoColor,
 		c.URL,
 		c.Version,
-		nomad.GetPageKeyHelp(firstPage, false, false, false, false, false, false, nomad.StdOut, false, !c.StartAllTasksView),
+		nomad.GetPageKeyHelp(firstPage, false, false, false, false, false, false, false, nomad.StdOut, false, getFirstMode(c)),
 	)
+	initialHeader.SetNamespace(c.Namespace)
+	initialHeader.SetProfile(c.Profile.Name, c.Profile.Color)
+	initialHeader.SetRegion(c.Region)
 	return Model{
-		config:      c,
-		header:      initialHeader,
-		currentPage: firstPage,
-		updateID:    nextUpdateID(),
-		inJobsMode:  !c.StartAllTasksView,
+		config:       c,
+		header:       initialHeader,
+		currentPage:  firstPage,
+		updateID:     nextUpdateID(),
+		mode:         getFirstMode(c),
+		expandedJobs: make(map[string]bool),
+
+		logReceiveTimes: c.Log.ReceiveTimes,
 	}
 }
 
@@ -134,8 +249,15 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	currentPageModel := m.getCurrentPageModel()
 	if currentPageModel != nil && currentPageModel.EnteringInput() {
+		promptingForInput := currentPageModel.PromptingForInput()
 		*currentPageModel, cmd = currentPageModel.Update(msg)
 		cmds = append(cmds, cmd)
+
+		// keys typed into input prompted for by an action are only for the page, apart from exiting
+		if keyMsg, isKeyMsg := msg.(tea.KeyMsg); isKeyMsg && promptingForInput && keyMsg.String() != "ctrl+c" {
+			m.updateKeyHelp()
+			return m, tea.Batch(cmds...)
+		}
 	}
 
 	switch msg := msg.(type) {
@@ -180,16 +302,22 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.getCurrentPageModel().ResetContextFilter()
 			}
 			m.getCurrentPageModel().SetLoading(false)
+			if msg.PartialErr != nil {
+				cmds = append(cmds, m.getCurrentPageModel().ShowToast(fmt.Sprintf("Error: %s", msg.PartialErr), true))
+			}
 
 			if m.currentPage.CanBeFirstPage() && len(msg.AllPageRows) == 0 {
 				// oddly, nomad http api errors when one provides the wrong token,
 				// but returns empty results when one provides an empty token
 				m.getCurrentPageModel().SetHeader([]string{"Error"})
 				m.getCurrentPageModel().SetAllPageRows([]page.Row{
-					{"", "No results. Is the cluster empty or was no nomad token provided?"},
-					{"", "Press q or ctrl+c to quit."},
+					{Key: "", Row: "No results. Is the cluster empty or was no nomad token provided?"},
+					{Key: "", Row: "Press q or ctrl+c to quit."},
 				})
 				m.getCurrentPageModel().SetViewportSelectionEnabled(false)
+			} else if m.currentPage.CanBeFirstPage() {
+				// re-enable selection if results appear, e.g. after switching back from an empty namespace
+				m.getCurrentPageModel().SetViewportSelectionEnabled(true)
 			}
 
 			switch m.currentPage {
@@ -200,12 +328,38 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.eventsStream = msg.EventsStream
 				cmds = append(cmds, nomad.ReadEventsStreamNextMessage(m.eventsStream, m.config.Event.AllocJQQuery))
 			case nomad.LogsPage:
+				// the logs start again from the offset, so they'd be recorded twice
+				cmds = append(cmds, m.stopLogRecording())
+				m.clearLogsPause()
+				m.jsonLogs = msg.JSONLogs
+				m.jsonLogs.MaxLines = m.config.Log.MaxLines
 				m.getCurrentPageModel().SetViewportSelectionToBottom()
-				if m.config.Log.Tail {
+				m.jobLogsStream.Close()
+				m.jobLogsStream = msg.JobLogsStream
+				if m.jobLogsStream.Chan != nil {
+					// stdout and stderr are interleaved line by line, whether following them or not
+					cmds = append(cmds, nomad.ReadJobLogsStreamNextMessage(m.jobLogsStream))
+				} else if m.config.Log.Tail {
 					m.logsStream = msg.LogsStream
 					m.lastLogFinished = true
 					cmds = append(cmds, nomad.ReadLogsStreamNextMessage(m.logsStream))
 				}
+			case nomad.JobLogsPage:
+				m.clearLogsPause()
+				m.jobLogsStream.Close()
+				m.jobLogsStream = msg.JobLogsStream
+				m.jobLogLines = nil
+				prefixStyle := make(map[string]lipgloss.Style)
+				for i, alloc := range m.jobLogsStream.Allocs {
+					prefixStyle[nomad.JobLogPrefix(alloc.ID)] = style.LogSources[i%len(style.LogSources)]
+				}
+				m.getCurrentPageModel().SetViewportPrefixStyle(prefixStyle)
+				cmds = append(cmds, nomad.ReadJobLogsStreamNextMessage(m.jobLogsStream))
+			case nomad.LogSearchPage:
+				m.logSearch = msg.LogSearch
+				m.getCurrentPageModel().SetFilter(m.logSearch.Query)
+				m.getCurrentPageModel().SetFilterPrefix(m.getFilterPrefix(m.currentPage) + " " + m.logSearch.Progress(false))
+				cmds = append(cmds, nomad.ContinueLogSearch(m.client, m.logSearch))
 			case nomad.ExecPage:
 				m.getCurrentPageModel().SetInputPrefix("Enter command: ")
 			}
@@ -236,29 +390,176 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case nomad.LogsStreamMsg:
 		if m.currentPage == nomad.LogsPage && m.logType == msg.Type {
+			cmds = append(cmds, m.recordLogs(msg.Value))
+		}
+		if m.currentPage == nomad.LogsPage && m.logType == msg.Type && m.jsonLogs.Enabled() {
+			m.appendLogRows(m.jsonLogs.Append(msg.Value, m.receiveTimeStamp()))
+			cmds = append(cmds, nomad.ReadLogsStreamNextMessage(m.logsStream))
+		} else if m.currentPage == nomad.LogsPage && m.logType == msg.Type {
 			logLines := strings.Split(msg.Value, "\n")
 
 			// finish with the last log line if necessary
 			if !m.lastLogFinished {
-				m.getCurrentPageModel().AppendToViewport([]page.Row{{Row: logLines[0]}}, false)
+				m.finishLastLogRow(logLines[0])
 				logLines = logLines[1:]
 			}
 
 			// append all the new log rows in this chunk to the viewport at once
-			scrollDown := m.getCurrentPageModel().ViewportSelectionAtBottom()
 			var allRows []page.Row
+			stamp := m.receiveTimeStamp()
 			for _, logLine := range logLines {
+				if logLine != "" {
+					logLine = stamp + logLine
+				}
 				allRows = append(allRows, page.Row{Row: logLine})
 			}
-			m.getCurrentPageModel().AppendToViewport(allRows, true)
-			if scrollDown {
-				m.getCurrentPageModel().ScrollViewportToBottom()
-			}
+			m.appendLogRows(allRows, false)
 
 			m.lastLogFinished = strings.HasSuffix(msg.Value, "\n")
 			cmds = append(cmds, nomad.ReadLogsStreamNextMessage(m.logsStream))
 		}
 
+	case nomad.JobLogsStreamMsg:
+		if msg.Chan == m.jobLogsStream.Chan && m.currentPage == nomad.LogsPage {
+			cmds = append(cmds, m.appendTaskLogLines(msg.Lines))
+			if !msg.Done {
+				cmds = append(cmds, nomad.ReadJobLogsStreamNextMessage(m.jobLogsStream))
+			}
+		} else if msg.Chan == m.jobLogsStream.Chan {
+			var rows []page.Row
+			for _, line := range msg.Lines {
+				if line.Err != nil {
+					toast := fmt.Sprintf("Error streaming logs of %s in %s: %s", line.TaskName, formatter.ShortAllocID(line.AllocID), line.Err)
+					cmds = append(cmds, m.pageModels[nomad.JobLogsPage].ShowToast(toast, true))
+					continue
+				}
+				m.jobLogLines = append(m.jobLogLines, line)
+				if !m.hiddenJobLogAllocs[line.AllocID] {
+					rows = append(rows, nomad.JobLogRow(line, m.jobLogsStream.LogType))
+				}
+			}
+			if maxLines := m.config.Log.MaxLines; maxLines > 0 && len(m.jobLogLines) > maxLines {
+				m.jobLogLines = m.jobLogLines[len(m.jobLogLines)-maxLines:]
+			}
+			if m.currentPage == nomad.JobLogsPage && m.logsPaused {
+				// shown from the buffered lines on resume
+				m.pausedLineCount += len(rows)
+				m.setLogsFilterPrefix()
+			} else if m.currentPage == nomad.JobLogsPage {
+				// sticky scroll down, as for the logs of a single task
+				scrollDown := m.getCurrentPageModel().ViewportSelectionAtBottom()
+				m.getCurrentPageModel().AppendToViewport(rows, true)
+				if scrollDown {
+					m.getCurrentPageModel().ScrollViewportToBottom()
+				}
+			}
+			if !msg.Done {
+				cmds = append(cmds, nomad.ReadJobLogsStreamNextMessage(m.jobLogsStream))
+			}
+		}
+
+	case nomad.LogSearchProgressMsg:
+		if m.currentPage == nomad.LogSearchPage && msg.Search.ID == m.logSearch.ID {
+			if msg.Err != nil {
+				m.getCurrentPageModel().SetLoading(false)
+				return m, m.getCurrentPageModel().ShowToast(fmt.Sprintf("Error: %s", msg.Err), true)
+			}
+			firstMatches := m.logSearch.NumMatches == 0 && len(msg.Matches) > 0
+			m.logSearch = msg.Search
+			m.getCurrentPageModel().AppendToViewport(msg.Matches, true)
+			if firstMatches {
+				// jump to the first match as soon as it's found
+				m.getCurrentPageModel().ResetContextFilter()
+			}
+			m.getCurrentPageModel().SetFilterPrefix(m.getFilterPrefix(m.currentPage) + " " + m.logSearch.Progress(msg.Done))
+			if msg.Done {
+				cmds = append(cmds, m.getCurrentPageModel().ShowToast(fmt.Sprintf("Found %d matching lines", m.logSearch.NumMatches), false))
+			} else {
+				cmds = append(cmds, nomad.ContinueLogSearch(m.client, m.logSearch))
+			}
+		}
+
+	case nomad.ActionCompleteMsg:
+		if pageModel, exists := m.pageModels[msg.Page]; exists {
+			if msg.Err != nil {
+				cmds = append(cmds, pageModel.ShowToast(fmt.Sprintf("Error: %s", msg.Err), true))
+			} else {
+				cmds = append(cmds, pageModel.ShowToast(msg.Message, false))
+			}
+		}
+		if msg.Page == m.currentPage && m.currentPage.DoesReload() {
+			cmds = append(cmds, m.getCurrentPageCmd())
+		}
+
+	case nomad.LogsDownloadedMsg:
+		if msg.Err != nil {
+			cmds = append(cmds, m.pageModels[nomad.LogsPage].ShowToast(fmt.Sprintf("Error: %s", msg.Err), true))
+		} else {
+			cmds = append(cmds, m.pageModels[nomad.LogsPage].ShowToast(msg.Message, false))
+		}
+
+	case nomad.ServiceAllocMsg:
+		if m.currentPage == nomad.ServicePage {
+			if msg.Err != nil {
+				return m, m.getCurrentPageModel().ShowToast(fmt.Sprintf("Error: %s", msg.Err), true)
+			}
+			m.alloc, m.taskName = msg.Alloc, msg.TaskName
+			m.setPage(nomad.LogsPage)
+			return m, m.getCurrentPageCmd()
+		}
+
+	case nomad.VariableOpenedMsg:
+		if msg.Err != nil {
+			return m, m.getCurrentPageModel().ShowToast(fmt.Sprintf("Error: %s", msg.Err), true)
+		}
+		return m, nomad.EditVariable(msg)
+
+	case nomad.VariableEditedMsg:
+		return m, nomad.SaveVariable(m.client, msg)
+
+	case nomad.VariableDeletedMsg:
+		if msg.Err != nil {
+			return m, m.getCurrentPageModel().ShowToast(fmt.Sprintf("Error: %s", msg.Err), true)
+		}
+		if m.currentPage == nomad.VariablePage {
+			m.setPage(nomad.VariablesPage)
+		}
+		if m.currentPage == nomad.VariablesPage {
+			cmds = append(cmds, m.getCurrentPageCmd())
+		}
+		cmds = append(cmds, m.getCurrentPageModel().ShowToast(msg.Message, false))
+
+	case nomad.JobLaunchInfoMsg:
+		if msg.Page == m.currentPage {
+			switch {
+			case msg.Err != nil:
+				return m, m.getCurrentPageModel().ShowToast(fmt.Sprintf("Error: %s", msg.Err), true)
+			case msg.Periodic:
+				m.getCurrentPageModel().RequestConfirmation(
+					fmt.Sprintf("Force launch periodic job %s in namespace %s?", msg.JobID, msg.Namespace),
+					nomad.ForcePeriodicLaunch(m.client, msg),
+				)
+				m.updateKeyHelp()
+				return m, nil
+			default:
+				m.launchInfo = msg
+				cmd = m.getCurrentPageModel().PromptForInput(nomad.DispatchPrompt(msg), "")
+				m.updateKeyHelp()
+				return m, cmd
+			}
+		}
+
+	case nomad.ChildJobLaunchedMsg:
+		if m.currentPage == nomad.JobsPage {
+			m.jobID, m.jobNamespace = msg.ChildJobID, msg.Namespace
+			if msg.Region != "" {
+				m.client.SetRegion(msg.Region)
+			}
+			m.setPage(nomad.JobTasksPage)
+			cmds = append(cmds, m.getCurrentPageCmd())
+		}
+		cmds = append(cmds, m.getCurrentPageModel().ShowToast(msg.Message, false))
+
 	case nomad.UpdatePageDataMsg:
 		if msg.ID == m.updateID && msg.Page == m.currentPage {
 			cmds = append(cmds, m.getCurrentPageCmd())
@@ -270,6 +571,29 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.getCurrentPageModel().SetLoading(true)
 			return m, nomad.InitiateWebSocket(m.config.URL, m.config.Token, m.alloc.ID, m.taskName, msg.Input)
 		}
+		if m.currentPage == nomad.JobsPage {
+			return m, nomad.DispatchJob(m.client, m.launchInfo, msg.Input)
+		}
+		if m.currentPage == nomad.AllocFilesPage || m.currentPage == nomad.AllocFilePage {
+			return m, nomad.DownloadAllocFile(m.client, m.alloc, m.allocFileToDownload, msg.Input, m.currentPage)
+		}
+		if m.currentPage == nomad.LogsPage || m.currentPage == nomad.JobLogsPage {
+			return m, m.handleLogsInput(msg.Input)
+		}
+		if m.currentPage == nomad.VariablesPage {
+			path := strings.TrimSpace(msg.Input)
+			if path == "" {
+				return m, m.getCurrentPageModel().ShowToast("Error: variable path is required", true)
+			}
+			return m, nomad.OpenVariable(m.client, path, m.newVariableNamespace(), true, m.currentPage)
+		}
+		if m.currentPage == nomad.JobTasksPage {
+			count, err := strconv.Atoi(strings.TrimSpace(msg.Input))
+			if err != nil || count < 0 {
+				return m, m.getCurrentPageModel().ShowToast(fmt.Sprintf("Error: invalid count %q", msg.Input), true)
+			}
+			return m, nomad.ScaleTaskGroup(m.client, m.jobID, m.jobNamespace, m.scaleTaskGroup, count, m.currentPage)
+		}
 
 	case nomad.ExecWebSocketConnectedMsg:
 		m.execWebSocket = msg.WebSocketConnection
@@ -336,12 +660,7 @@ func (m *Model) initialize() error {
 
 	firstPage := getFirstPage(m.config)
 
-	m.pageModels = make(map[nomad.Page]*page.Model)
-	for k, pageConfig := range nomad.GetAllPageConfigs(m.width, m.getPageHeight(), m.config.CompactTables) {
-		startFiltering := m.config.StartFiltering && k == firstPage
-		p := page.New(pageConfig, m.config.CopySavePath, startFiltering, m.config.FilterWithContext)
-		m.pageModels[k] = &p
-	}
+	m.initializePageModels(firstPage)
 
 	if m.config.StartCompact {
 		m.toggleCompact()
@@ -353,11 +672,26 @@ func (m *Model) initialize() error {
 	return nil
 }
 
+func (m *Model) initializePageModels(startFilteringPage nomad.Page) {
+	m.pageModels = make(map[nomad.Page]*page.Model)
+	for k, pageConfig := range nomad.GetAllPageConfigs(m.width, m.getPageHeight(), m.config.CompactTables) {
+		startFiltering := m.config.StartFiltering && k == startFilteringPage
+		p := page.New(pageConfig, m.config.CopySavePath, startFiltering, m.config.FilterWithContext)
+		if k == nomad.LogsPage || k == nomad.JobLogsPage {
+			p.SetMaxRows(m.config.Log.MaxLines)
+		}
+		m.pageModels[k] = &p
+	}
+}
+
 func (m *Model) cleanupCmd() tea.Cmd {
 	return func() tea.Msg {
 		if m.execWebSocket != nil {
 			nomad.CloseWebSocket(m.execWebSocket)()
 		}
+		if m.logRecording != nil {
+			m.logRecording.Close()
+		}
 		return message.CleanupCompleteMsg{}
 	}
 }
@@ -377,13 +711,19 @@ func (m *Model) handleKeyMsg(msg tea.KeyMsg) tea.Cmd {
 		addingQToFilter := m.currentPageFilterFocused()
 		saving := m.currentPageViewportSaving()
 		enteringInput := currentPageModel != nil && currentPageModel.EnteringInput()
-		typingQLegitimately := msg.String() == "q" && (addingQToFilter || saving || enteringInput || m.inPty)
+		confirming := currentPageModel != nil && currentPageModel.Confirming()
+		typingQLegitimately := msg.String() == "q" && (addingQToFilter || saving || enteringInput || confirming || m.inPty)
 		ctrlCInPty := m.inPty && msg.String() == "ctrl+c"
 		if (!ctrlCInPty && !typingQLegitimately) || m.err != nil {
 			return m.cleanupCmd()
 		}
 	}
 
+	// the page handles confirming or cancelling the pending action
+	if currentPageModel != nil && currentPageModel.Confirming() {
+		return nil
+	}
+
 	if m.currentPage == nomad.ExecPage {
 		var keypress string
 		if m.inPty {
@@ -409,11 +749,71 @@ func (m *Model) handleKeyMsg(msg tea.KeyMsg) tea.Cmd {
 			if selectedPageRow, err := m.getCurrentPageModel().GetSelectedPageRow(); err == nil {
 				switch m.currentPage {
 				case nomad.JobsPage:
-					m.jobID, m.jobNamespace = nomad.JobIDAndNamespaceFromKey(selectedPageRow.Key)
+					m.selectJob(selectedPageRow.Key)
+				case nomad.NodesPage:
+					m.nodeID, m.nodeName = nomad.NodeIDAndNameFromKey(selectedPageRow.Key)
+				case nomad.JobEvaluationsPage:
+					m.evalID, m.evalNamespace = nomad.EvalIDAndNamespaceFromKey(selectedPageRow.Key)
+				case nomad.JobVersionsPage:
+					if err := m.setDiffVersions(selectedPageRow.Key); err != nil {
+						m.err = err
+						return nil
+					}
 				case nomad.JobEventsPage, nomad.AllocEventsPage, nomad.AllEventsPage:
 					m.event = selectedPageRow.Key
 				case nomad.LogsPage:
 					m.logline = selectedPageRow.Row
+					if selectedPageRow.Key != "" {
+						// the row may only show the fields picked by a query, or start with a gutter
+						m.logline = selectedPageRow.Key
+					}
+				case nomad.JobLogAllocsPage:
+					m.hiddenJobLogAllocs[selectedPageRow.Key] = !m.hiddenJobLogAllocs[selectedPageRow.Key]
+					return m.getCurrentPageCmd()
+				case nomad.AllocFilesPage:
+					filePath, isDir := nomad.AllocFileFromKey(selectedPageRow.Key)
+					m.allocFilePath = filePath
+					if isDir {
+						m.setPage(nomad.AllocFilesPage)
+						return m.getCurrentPageCmd()
+					}
+				case nomad.ProfilesPage:
+					for _, profile := range m.config.Profiles {
+						if profile.Name == selectedPageRow.Key {
+							return m.setProfile(profile)
+						}
+					}
+					return nil
+				case nomad.NamespacesPage:
+					m.setNamespace(selectedPageRow.Key)
+					m.setPage(nomad.ModeRootPage(m.mode))
+					return m.getCurrentPageCmd()
+				case nomad.RegionsPage:
+					m.setRegion(selectedPageRow.Key)
+					m.setPage(nomad.ModeRootPage(m.mode))
+					return m.getCurrentPageCmd()
+				case nomad.VolumesPage:
+					m.volumeID, m.volumeNamespace = nomad.VolumeIDAndNamespaceFromKey(selectedPageRow.Key)
+				case nomad.ServicesPage:
+					m.serviceName, m.serviceNamespace = nomad.ServiceNameAndNamespaceFromKey(selectedPageRow.Key)
+				case nomad.ServicePage:
+					allocID, allocNamespace, registrationID := nomad.ServiceRegistrationFromKey(selectedPageRow.Key)
+					return nomad.FetchServiceAlloc(m.client, allocID, allocNamespace, registrationID)
+				case nomad.VariablesPage:
+					path, namespace, _, err := nomad.VariableFromKey(selectedPageRow.Key)
+					if err != nil {
+						m.err = err
+						return nil
+					}
+					m.variablePath, m.variableNamespace = path, namespace
+					m.setRevealVariableValues(false)
+				case nomad.SignalPage:
+					signal := selectedPageRow.Key
+					m.getCurrentPageModel().RequestConfirmation(
+						fmt.Sprintf("Send %s to task %s in %s?", signal, m.taskName, m.alloc.Name),
+						nomad.SignalTask(m.client, m.alloc, m.taskName, signal, m.currentPage),
+					)
+					return nil
 				default:
 					if m.currentPage.ShowsTasks() {
 						taskInfo, err := nomad.TaskInfoFromKey(selectedPageRow.Key)
@@ -440,9 +840,22 @@ func (m *Model) handleKeyMsg(msg tea.KeyMsg) tea.Cmd {
 						cmds = append(cmds, nomad.CloseWebSocket(m.execWebSocket))
 					}
 					m.getCurrentPageModel().SetDoesNeedNewInput()
+				case nomad.AllocFilesPage:
+					if m.allocFilePath != nomad.AllocFilesRoot {
+						m.allocFilePath = path.Dir(m.allocFilePath)
+						m.setPage(nomad.AllocFilesPage)
+						return m.getCurrentPageCmd()
+					}
+				case nomad.AllocFilePage:
+					m.allocFilePath = path.Dir(m.allocFilePath)
+				case nomad.JobLogAllocsPage:
+					// keep streaming rather than starting over
+					m.setPage(nomad.JobLogsPage)
+					m.showJobLogLines()
+					return nil
 				}
 
-				backPage := m.currentPage.Backward(m.inJobsMode)
+				backPage := m.currentPage.Backward(m.mode)
 				if backPage != m.currentPage {
 					m.setPage(backPage)
 					cmds = append(cmds, m.getCurrentPageCmd())
@@ -495,9 +908,13 @@ func (m *Model) handleKeyMsg(msg tea.KeyMsg) tea.Cmd {
 			if selectedPageRow, err := m.getCurrentPageModel().GetSelectedPageRow(); err == nil {
 				switch m.currentPage {
 				case nomad.JobsPage:
-					m.jobID, m.jobNamespace = nomad.JobIDAndNamespaceFromKey(selectedPageRow.Key)
+					m.selectJob(selectedPageRow.Key)
 					m.setPage(nomad.JobSpecPage)
 					return m.getCurrentPageCmd()
+				case nomad.NodesPage:
+					m.nodeID, m.nodeName = nomad.NodeIDAndNameFromKey(selectedPageRow.Key)
+					m.setPage(nomad.NodeSpecPage)
+					return m.getCurrentPageCmd()
 				default:
 					if m.currentPage.ShowsTasks() {
 						taskInfo, err := nomad.TaskInfoFromKey(selectedPageRow.Key)
@@ -515,36 +932,166 @@ func (m *Model) handleKeyMsg(msg tea.KeyMsg) tea.Cmd {
 
 		if key.Matches(msg, keymap.KeyMap.JobEvents) && m.currentPage == nomad.JobsPage {
 			if selectedPageRow, err := m.getCurrentPageModel().GetSelectedPageRow(); err == nil {
-				m.jobID, m.jobNamespace = nomad.JobIDAndNamespaceFromKey(selectedPageRow.Key)
+				m.selectJob(selectedPageRow.Key)
 				m.setPage(nomad.JobEventsPage)
 				return m.getCurrentPageCmd()
 			}
 		}
 
-		if key.Matches(msg, keymap.KeyMap.TasksMode) && m.currentPage == nomad.JobsPage {
-			if m.inJobsMode {
-				m.setPage(nomad.AllTasksPage)
-				m.inJobsMode = false
+		if key.Matches(msg, keymap.KeyMap.Namespaces) && m.currentPage.IsModeRoot() && m.currentPage != nomad.NodesPage {
+			m.setPage(nomad.NamespacesPage)
+			return m.getCurrentPageCmd()
+		}
+
+		if m.currentPage == nomad.VolumesPage {
+			switch {
+			case key.Matches(msg, keymap.KeyMap.Plugins):
+				m.setPage(nomad.PluginsPage)
+				return m.getCurrentPageCmd()
+			case key.Matches(msg, keymap.KeyMap.HostVolumes):
+				m.setPage(nomad.HostVolumesPage)
 				return m.getCurrentPageCmd()
 			}
 		}
 
-		if key.Matches(msg, keymap.KeyMap.JobsMode) && m.currentPage == nomad.AllTasksPage {
-			if !m.inJobsMode {
-				m.setPage(nomad.JobsPage)
-				m.inJobsMode = true
-				return m.getCurrentPageCmd()
+		if key.Matches(msg, keymap.KeyMap.Variables) && m.currentPage.IsModeRoot() {
+			m.setPage(nomad.VariablesPage)
+			return m.getCurrentPageCmd()
+		}
+
+		if key.Matches(msg, keymap.KeyMap.NewVariable) && m.currentPage == nomad.VariablesPage {
+			prompt := fmt.Sprintf("Path of new variable in namespace %s: ", m.newVariableNamespace())
+			return m.getCurrentPageModel().PromptForInput(prompt, "")
+		}
+
+		if key.Matches(msg, keymap.KeyMap.RevealValues) && m.currentPage == nomad.VariablePage {
+			m.setRevealVariableValues(!m.revealVariableValues)
+			return m.getCurrentPageCmd()
+		}
+
+		if m.currentPage == nomad.VariablesPage || m.currentPage == nomad.VariablePage {
+			if selectedPageRow, err := m.getCurrentPageModel().GetSelectedPageRow(); err == nil {
+				path, namespace, modifyIndex, err := nomad.VariableFromKey(selectedPageRow.Key)
+				if err != nil {
+					m.err = err
+					return nil
+				}
+				switch {
+				case key.Matches(msg, keymap.KeyMap.EditVariable):
+					return nomad.OpenVariable(m.client, path, namespace, false, m.currentPage)
+				case key.Matches(msg, keymap.KeyMap.DeleteVariable):
+					m.getCurrentPageModel().RequestConfirmation(
+						fmt.Sprintf("Delete variable %s in namespace %s? This cannot be undone", path, namespace),
+						nomad.DeleteVariable(m.client, path, namespace, modifyIndex),
+					)
+					return nil
+				}
+			}
+		}
+
+		if key.Matches(msg, keymap.KeyMap.Regions) && m.currentPage.IsModeRoot() {
+			m.setPage(nomad.RegionsPage)
+			return m.getCurrentPageCmd()
+		}
+
+		if key.Matches(msg, keymap.KeyMap.Profiles) && m.currentPage.IsModeRoot() {
+			m.setPage(nomad.ProfilesPage)
+			return m.getCurrentPageCmd()
+		}
+
+		if m.currentPage.IsModeRoot() {
+			switch {
+			case key.Matches(msg, keymap.KeyMap.JobsMode) && m.mode != nomad.JobsMode:
+				return m.setMode(nomad.JobsMode, nomad.JobsPage)
+			case key.Matches(msg, keymap.KeyMap.TasksMode) && m.mode != nomad.AllTasksMode:
+				return m.setMode(nomad.AllTasksMode, nomad.AllTasksPage)
+			case key.Matches(msg, keymap.KeyMap.NodesMode) && m.mode != nomad.NodesMode:
+				return m.setMode(nomad.NodesMode, nomad.NodesPage)
+			case key.Matches(msg, keymap.KeyMap.ServicesMode) && m.mode != nomad.ServicesMode:
+				return m.setMode(nomad.ServicesMode, nomad.ServicesPage)
+			case key.Matches(msg, keymap.KeyMap.VolumesMode) && m.mode != nomad.VolumesMode:
+				return m.setMode(nomad.VolumesMode, nomad.VolumesPage)
 			}
 		}
 
 		if key.Matches(msg, keymap.KeyMap.JobMeta) && m.currentPage == nomad.JobsPage {
 			if selectedPageRow, err := m.getCurrentPageModel().GetSelectedPageRow(); err == nil {
-				m.jobID, m.jobNamespace = nomad.JobIDAndNamespaceFromKey(selectedPageRow.Key)
+				m.selectJob(selectedPageRow.Key)
 				m.setPage(nomad.JobMetaPage)
 				return m.getCurrentPageCmd()
 			}
 		}
 
+		if m.currentPage == nomad.JobTasksPage {
+			switch {
+			case key.Matches(msg, keymap.KeyMap.JobLogs):
+				return m.showJobLogs("")
+			case key.Matches(msg, keymap.KeyMap.TaskGroupLogs):
+				if selectedPageRow, err := m.getCurrentPageModel().GetSelectedPageRow(); err == nil {
+					taskInfo, err := nomad.TaskInfoFromKey(selectedPageRow.Key)
+					if err != nil {
+						m.err = err
+						return nil
+					}
+					return m.showJobLogs(taskInfo.Alloc.TaskGroup)
+				}
+			}
+		}
+
+		if key.Matches(msg, keymap.KeyMap.Scale) && m.currentPage == nomad.JobTasksPage {
+			if selectedPageRow, err := m.getCurrentPageModel().GetSelectedPageRow(); err == nil {
+				taskInfo, err := nomad.TaskInfoFromKey(selectedPageRow.Key)
+				if err != nil {
+					m.err = err
+					return nil
+				}
+				m.scaleTaskGroup = taskInfo.Alloc.TaskGroup
+				prompt := fmt.Sprintf("New count for task group %s of job %s: ", m.scaleTaskGroup, m.jobID)
+				return m.getCurrentPageModel().PromptForInput(prompt, "")
+			}
+		}
+
+		if m.currentPage.ShowsTasks() {
+			if selectedPageRow, err := m.getCurrentPageModel().GetSelectedPageRow(); err == nil {
+				taskInfo, err := nomad.TaskInfoFromKey(selectedPageRow.Key)
+				if err != nil {
+					m.err = err
+					return nil
+				}
+				alloc, taskName := taskInfo.Alloc, taskInfo.TaskName
+				shortID := formatter.ShortAllocID(alloc.ID)
+				switch {
+				case key.Matches(msg, keymap.KeyMap.RestartTask):
+					m.getCurrentPageModel().RequestConfirmation(
+						fmt.Sprintf("Restart task %s in %s %s?", taskName, alloc.Name, shortID),
+						nomad.RestartTask(m.client, alloc, taskName, m.currentPage),
+					)
+					return nil
+				case key.Matches(msg, keymap.KeyMap.RestartAlloc):
+					m.getCurrentPageModel().RequestConfirmation(
+						fmt.Sprintf("Restart all tasks in allocation %s %s?", alloc.Name, shortID),
+						nomad.RestartAlloc(m.client, alloc, m.currentPage),
+					)
+					return nil
+				case key.Matches(msg, keymap.KeyMap.StopAlloc):
+					m.getCurrentPageModel().RequestConfirmation(
+						fmt.Sprintf("Stop allocation %s %s? It will be rescheduled if its job is running", alloc.Name, shortID),
+						nomad.StopAlloc(m.client, alloc, m.currentPage),
+					)
+					return nil
+				case key.Matches(msg, keymap.KeyMap.Signal):
+					m.alloc, m.taskName = alloc, taskName
+					m.setPage(nomad.SignalPage)
+					return m.getCurrentPageCmd()
+				case key.Matches(msg, keymap.KeyMap.AllocFiles):
+					m.alloc, m.taskName = alloc, taskName
+					m.allocFilePath = nomad.AllocFilesRoot
+					m.setPage(nomad.AllocFilesPage)
+					return m.getCurrentPageCmd()
+				}
+			}
+		}
+
 		if key.Matches(msg, keymap.KeyMap.AllocEvents) && m.currentPage.ShowsTasks() {
 			if selectedPageRow, err := m.getCurrentPageModel().GetSelectedPageRow(); err == nil {
 				taskInfo, err := nomad.TaskInfoFromKey(selectedPageRow.Key)
@@ -563,12 +1110,156 @@ func (m *Model) handleKeyMsg(msg tea.KeyMsg) tea.Cmd {
 			return m.getCurrentPageCmd()
 		}
 
-		if m.currentPage == nomad.LogsPage {
+		if key.Matches(msg, keymap.KeyMap.JobDeployments) && m.currentPage == nomad.JobsPage {
+			if selectedPageRow, err := m.getCurrentPageModel().GetSelectedPageRow(); err == nil {
+				m.selectJob(selectedPageRow.Key)
+				m.setPage(nomad.JobDeploymentsPage)
+				return m.getCurrentPageCmd()
+			}
+		}
+
+		if key.Matches(msg, keymap.KeyMap.JobEvaluations) && m.currentPage == nomad.JobsPage {
+			if selectedPageRow, err := m.getCurrentPageModel().GetSelectedPageRow(); err == nil {
+				m.selectJob(selectedPageRow.Key)
+				m.setPage(nomad.JobEvaluationsPage)
+				return m.getCurrentPageCmd()
+			}
+		}
+
+		if key.Matches(msg, keymap.KeyMap.JobVersions) && m.currentPage == nomad.JobsPage {
+			if selectedPageRow, err := m.getCurrentPageModel().GetSelectedPageRow(); err == nil {
+				m.selectJob(selectedPageRow.Key)
+				m.markedJobVersion = ""
+				m.setPage(nomad.JobVersionsPage)
+				return m.getCurrentPageCmd()
+			}
+		}
+
+		if m.currentPage == nomad.JobsPage {
+			if selectedPageRow, err := m.getCurrentPageModel().GetSelectedPageRow(); err == nil {
+				jobID, jobNamespace := nomad.JobIDAndNamespaceFromKey(selectedPageRow.Key)
+				client := m.jobClient(selectedPageRow.Key)
+				switch {
+				case key.Matches(msg, keymap.KeyMap.StopJob):
+					m.getCurrentPageModel().RequestConfirmation(
+						fmt.Sprintf("Stop job %s in namespace %s?", jobID, jobNamespace),
+						nomad.StopJob(client, jobID, jobNamespace, false, m.currentPage),
+					)
+					return nil
+				case key.Matches(msg, keymap.KeyMap.PurgeJob):
+					m.getCurrentPageModel().RequestConfirmation(
+						fmt.Sprintf("Stop and purge job %s in namespace %s? This cannot be undone", jobID, jobNamespace),
+						nomad.StopJob(client, jobID, jobNamespace, true, m.currentPage),
+					)
+					return nil
+				case key.Matches(msg, keymap.KeyMap.StartJob):
+					m.getCurrentPageModel().RequestConfirmation(
+						fmt.Sprintf("Start job %s in namespace %s?", jobID, jobNamespace),
+						nomad.StartJob(client, jobID, jobNamespace, m.currentPage),
+					)
+					return nil
+				case key.Matches(msg, keymap.KeyMap.ExpandJob):
+					expandableKey := nomad.ExpandableJobKeyFromKey(selectedPageRow.Key)
+					m.expandedJobs[expandableKey] = !m.expandedJobs[expandableKey]
+					return m.getCurrentPageCmd()
+				case key.Matches(msg, keymap.KeyMap.LaunchJob):
+					return nomad.FetchJobLaunchInfo(m.client, jobID, jobNamespace, nomad.JobRegionFromKey(selectedPageRow.Key), m.currentPage)
+				case key.Matches(msg, keymap.KeyMap.RestartJob):
+					m.getCurrentPageModel().RequestConfirmation(
+						fmt.Sprintf("Restart all allocations of job %s in namespace %s?", jobID, jobNamespace),
+						nomad.RestartJob(client, jobID, jobNamespace, m.currentPage),
+					)
+					return nil
+				}
+			}
+		}
+
+		if m.currentPage == nomad.JobVersionsPage {
+			if selectedPageRow, err := m.getCurrentPageModel().GetSelectedPageRow(); err == nil {
+				switch {
+				case key.Matches(msg, keymap.KeyMap.MarkVersion):
+					if m.markedJobVersion == selectedPageRow.Key {
+						m.markedJobVersion = ""
+					} else {
+						m.markedJobVersion = selectedPageRow.Key
+					}
+					m.getCurrentPageModel().SetFilterPrefix(m.getFilterPrefix(m.currentPage))
+					return nil
+				case key.Matches(msg, keymap.KeyMap.Revert):
+					version, err := nomad.JobVersionFromKey(selectedPageRow.Key)
+					if err != nil {
+						m.err = err
+						return nil
+					}
+					m.getCurrentPageModel().RequestConfirmation(
+						fmt.Sprintf("Revert job %s in namespace %s to version %d?", m.jobID, m.jobNamespace, version),
+						nomad.RevertJob(m.client, m.jobID, m.jobNamespace, version, m.currentPage),
+					)
+					return nil
+				}
+			}
+		}
+
+		if key.Matches(msg, keymap.KeyMap.AllDeployments) && m.currentPage == nomad.JobsPage {
+			m.setPage(nomad.AllDeploymentsPage)
+			return m.getCurrentPageCmd()
+		}
+
+		if m.currentPage.ShowsDeployments() {
+			if selectedPageRow, err := m.getCurrentPageModel().GetSelectedPageRow(); err == nil {
+				deployment := nomad.DeploymentInfoFromKey(selectedPageRow.Key)
+				shortID := formatter.ShortAllocID(deployment.ID)
+				switch {
+				case key.Matches(msg, keymap.KeyMap.Promote):
+					m.getCurrentPageModel().RequestConfirmation(
+						fmt.Sprintf("Promote canaries for deployment %s of job %s?", shortID, deployment.JobID),
+						nomad.PromoteDeployment(m.client, deployment, m.currentPage),
+					)
+					return nil
+				case key.Matches(msg, keymap.KeyMap.Fail):
+					m.getCurrentPageModel().RequestConfirmation(
+						fmt.Sprintf("Fail deployment %s of job %s?", shortID, deployment.JobID),
+						nomad.FailDeployment(m.client, deployment, m.currentPage),
+					)
+					return nil
+				case key.Matches(msg, keymap.KeyMap.PauseResume):
+					pause := deployment.Status != "paused"
+					action := "Resume"
+					if pause {
+						action = "Pause"
+					}
+					m.getCurrentPageModel().RequestConfirmation(
+						fmt.Sprintf("%s deployment %s of job %s?", action, shortID, deployment.JobID),
+						nomad.PauseDeployment(m.client, deployment, pause, m.currentPage),
+					)
+					return nil
+				}
+			}
+		}
+
+		if key.Matches(msg, keymap.KeyMap.Download) {
+			switch m.currentPage {
+			case nomad.AllocFilesPage:
+				if selectedPageRow, err := m.getCurrentPageModel().GetSelectedPageRow(); err == nil {
+					if filePath, isDir := nomad.AllocFileFromKey(selectedPageRow.Key); !isDir {
+						return m.promptForDownload(filePath)
+					}
+				}
+			case nomad.AllocFilePage:
+				return m.promptForDownload(m.allocFilePath)
+			case nomad.LogsPage:
+				m.logsInput = downloadLogsInput
+				prompt := fmt.Sprintf("Download all %s logs of task %s to (end with .gz to compress): ", m.logType.ShortString(), m.taskName)
+				return m.getCurrentPageModel().PromptForInput(prompt, nomad.LogFileName(m.taskName, m.logType))
+			}
+		}
+
+		if m.currentPage == nomad.LogsPage || m.currentPage == nomad.JobLogsPage {
 			switch {
 			case key.Matches(msg, keymap.KeyMap.StdOut):
 				if !m.currentPageLoading() && m.logType != nomad.StdOut {
 					m.logType = nomad.StdOut
-					m.getCurrentPageModel().SetViewportStyle(style.ViewportHeaderStyle, style.StdOut)
+					m.setLogsViewportStyle()
 					m.getCurrentPageModel().SetLoading(true)
 					return m.getCurrentPageCmd()
 				}
@@ -576,11 +1267,85 @@ func (m *Model) handleKeyMsg(msg tea.KeyMsg) tea.Cmd {
 			case key.Matches(msg, keymap.KeyMap.StdErr):
 				if !m.currentPageLoading() && m.logType != nomad.StdErr {
 					m.logType = nomad.StdErr
-					stdErrHeaderStyle := style.ViewportHeaderStyle.Copy().Inherit(style.StdErr)
-					m.getCurrentPageModel().SetViewportStyle(stdErrHeaderStyle, style.StdErr)
+					m.setLogsViewportStyle()
+					m.getCurrentPageModel().SetLoading(true)
+					return m.getCurrentPageCmd()
+				}
+
+			case key.Matches(msg, keymap.KeyMap.StdOutAndStdErr):
+				if !m.currentPageLoading() && m.logType != nomad.StdOutAndStdErr {
+					m.logType = nomad.StdOutAndStdErr
+					m.setLogsViewportStyle()
+					m.getCurrentPageModel().SetLoading(true)
+					return m.getCurrentPageCmd()
+				}
+
+			case key.Matches(msg, keymap.KeyMap.PauseLogs):
+				if !m.currentPageLoading() {
+					if m.logsPaused {
+						m.resumeLogs()
+					} else {
+						m.logsPaused = true
+					}
+					m.setLogsFilterPrefix()
+				}
+				return nil
+
+			case key.Matches(msg, keymap.KeyMap.JobLogAllocs) && m.currentPage == nomad.JobLogsPage:
+				m.setPage(nomad.JobLogAllocsPage)
+				return m.getCurrentPageCmd()
+
+			case key.Matches(msg, keymap.KeyMap.JSONLogs) && m.currentPage == nomad.LogsPage:
+				if !m.currentPageLoading() {
+					m.showJSONLogs = !m.showJSONLogs
 					m.getCurrentPageModel().SetLoading(true)
 					return m.getCurrentPageCmd()
 				}
+
+			case key.Matches(msg, keymap.KeyMap.ReceiveTimes) && m.currentPage == nomad.LogsPage:
+				if !m.currentPageLoading() {
+					m.logReceiveTimes = !m.logReceiveTimes
+					m.getCurrentPageModel().SetLoading(true)
+					return m.getCurrentPageCmd()
+				}
+
+			case key.Matches(msg, keymap.KeyMap.RecordLogs) && m.currentPage == nomad.LogsPage:
+				if m.logRecording != nil {
+					return m.stopLogRecording()
+				}
+				if !m.config.Log.Tail {
+					return m.getCurrentPageModel().ShowToast("Only followed logs can be recorded, see --log-tail", true)
+				}
+				if !m.currentPageLoading() {
+					m.logsInput = recordLogsInput
+					prompt := fmt.Sprintf("Record new %s logs of task %s to (end with .gz to compress): ", m.logType.ShortString(), m.taskName)
+					return m.getCurrentPageModel().PromptForInput(prompt, nomad.LogFileName(m.taskName, m.logType))
+				}
+
+			case key.Matches(msg, keymap.KeyMap.JumpToTime):
+				m.logsInput = jumpToTimeInput
+				return m.getCurrentPageModel().PromptForInput("Jump to time (e.g. 15:04, 2006-01-02T15:04:05Z or 10m ago as 10m): ", "")
+
+			case key.Matches(msg, keymap.KeyMap.LogsSince):
+				m.logsInput = logsSinceInput
+				return m.getCurrentPageModel().PromptForInput("Show logs from the last N minutes (empty for all): ", "")
+
+			case key.Matches(msg, keymap.KeyMap.MinLogLevel) && m.currentPage == nomad.LogsPage:
+				m.minLogLevel = m.minLogLevel.Next()
+				m.setLogLevelFilter()
+				m.setLogsFilterPrefix()
+				return nil
+
+			case key.Matches(msg, keymap.KeyMap.NextError) && m.currentPage == nomad.LogsPage:
+				return m.selectNextError(true)
+
+			case key.Matches(msg, keymap.KeyMap.PrevError) && m.currentPage == nomad.LogsPage:
+				return m.selectNextError(false)
+
+			case key.Matches(msg, keymap.KeyMap.SearchLogs) && m.currentPage == nomad.LogsPage:
+				m.logsInput = searchLogsInput
+				prompt := fmt.Sprintf("Search all %s logs of task %s for: ", m.logType.ShortString(), m.taskName)
+				return m.getCurrentPageModel().PromptForInput(prompt, "")
 			}
 		}
 	}
@@ -591,6 +1356,31 @@ func (m *Model) handleKeyMsg(msg tea.KeyMsg) tea.Cmd {
 func (m *Model) setPage(page nomad.Page) {
 	m.getCurrentPageModel().HideToast()
 	m.currentPage = page
+	if page != nomad.JobLogsPage && page != nomad.JobLogAllocsPage {
+		m.jobLogsStream.Close()
+	}
+	if page != nomad.LogsPage && m.logRecording != nil {
+		// logs are only followed on their page
+		m.logRecording.Close()
+		m.logRecording = nil
+	}
+	if page == nomad.LogsPage || page == nomad.LogSearchPage || page == nomad.JobLogsPage {
+		m.setLogsViewportStyle()
+	}
+	if page == nomad.LogsPage {
+		m.logLevels = nomad.NewLogLevels(m.config.Log.levelPatterns(m.alloc.JobID), m.config.Log.MaxLines)
+		m.setLogLevelFilter()
+	}
+	if page == nomad.LogsPage || page == nomad.JobLogsPage {
+		m.clearLogsPause()
+		m.logTimes = nomad.NewLogTimes(m.config.Log.MaxLines)
+		m.logsSince = time.Time{}
+		m.getCurrentPageModel().SetRowsFrom(nil)
+	}
+	if page.IsModeRoot() {
+		// undo selecting a job in another region when listing jobs in all regions
+		m.client.SetRegion(m.config.Region)
+	}
 	m.getCurrentPageModel().SetFilterPrefix(m.getFilterPrefix(page))
 	if page.DoesLoad() {
 		m.getCurrentPageModel().SetLoading(true)
@@ -599,6 +1389,411 @@ func (m *Model) setPage(page nomad.Page) {
 	}
 }
 
+// setDiffVersions sets the versions to diff between, which are the selected version and the marked version if there is
+// one, or the selected version and the version before it
+func (m *Model) setDiffVersions(selectedKey string) error {
+	selected, err := nomad.JobVersionFromKey(selectedKey)
+	if err != nil {
+		return err
+	}
+	m.diffFromVersion, m.diffToVersion = selected, selected
+	if selected > 0 {
+		m.diffFromVersion = selected - 1
+	}
+	if m.markedJobVersion != "" && m.markedJobVersion != selectedKey {
+		marked, err := nomad.JobVersionFromKey(m.markedJobVersion)
+		if err != nil {
+			return err
+		}
+		if marked < selected {
+			m.diffFromVersion = marked
+		} else {
+			m.diffFromVersion, m.diffToVersion = selected, marked
+		}
+	}
+	return nil
+}
+
+// showJobLogs streams the logs of the current job, limited to taskGroup if it's not empty
+func (m *Model) showJobLogs(taskGroup string) tea.Cmd {
+	m.jobLogsTaskGroup = taskGroup
+	m.hiddenJobLogAllocs = make(map[string]bool)
+	m.setPage(nomad.JobLogsPage)
+	return m.getCurrentPageCmd()
+}
+
+// showJobLogLines shows the job logs received so far from allocations that aren't hidden
+func (m *Model) showJobLogLines() {
+	var rows []page.Row
+	for _, line := range m.jobLogLines {
+		if !m.hiddenJobLogAllocs[line.AllocID] {
+			rows = append(rows, nomad.JobLogRow(line, m.jobLogsStream.LogType))
+		}
+	}
+	m.getCurrentPageModel().SetAllPageRows(rows)
+	m.getCurrentPageModel().SetLoading(false)
+	m.getCurrentPageModel().SetViewportSelectionToBottom()
+}
+
+// setLogsViewportStyle colors the current page for the type of logs being viewed
+func (m *Model) setLogsViewportStyle() {
+	if m.logType == nomad.StdErr {
+		stdErrHeaderStyle := style.ViewportHeaderStyle.Copy().Inherit(style.StdErr)
+		m.getCurrentPageModel().SetViewportStyle(stdErrHeaderStyle, style.StdErr)
+	} else {
+		m.getCurrentPageModel().SetViewportStyle(style.ViewportHeaderStyle, style.StdOut)
+	}
+	if m.currentPage == nomad.LogsPage {
+		// marks stderr when interleaved with stdout
+		m.getCurrentPageModel().SetViewportPrefixStyle(map[string]lipgloss.Style{nomad.LogGutter(nomad.StdErr): style.StdErr})
+	}
+}
+
+// appendTaskLogLines adds lines of the interleaved stdout and stderr of a task to its logs
+func (m *Model) appendTaskLogLines(lines []nomad.JobLogLine) tea.Cmd {
+	var cmds []tea.Cmd
+	var rows []page.Row
+	var recorded strings.Builder
+	rerenderJSON := false
+	stamp := m.receiveTimeStamp()
+	for _, line := range lines {
+		if line.Err != nil {
+			toast := fmt.Sprintf("Error streaming %s: %s", line.LogType.ShortString(), line.Err)
+			cmds = append(cmds, m.getCurrentPageModel().ShowToast(toast, true))
+			continue
+		}
+		recorded.WriteString(nomad.LogGutter(line.LogType) + line.Line + "\n")
+		if m.jsonLogs.Enabled() {
+			jsonRows, rerender := m.jsonLogs.AppendLines([]string{line.Line}, stamp+nomad.LogGutter(line.LogType))
+			rows, rerenderJSON = append(rows, jsonRows...), rerenderJSON || rerender
+		} else if strings.TrimSpace(line.Line) != "" {
+			row := nomad.TaskLogRow(line)
+			row.Row = stamp + row.Row
+			rows = append(rows, row)
+		}
+	}
+	m.appendLogRows(rows, rerenderJSON)
+	cmds = append(cmds, m.recordLogs(recorded.String()))
+	return tea.Batch(cmds...)
+}
+
+// appendLogRows adds rows to the logs of a task, or renders JSON logs again if their columns changed. While paused,
+// the rows are buffered instead
+func (m *Model) appendLogRows(rows []page.Row, rerenderJSON bool) {
+	if m.logsPaused {
+		for _, row := range rows {
+			if row.Row != "" {
+				m.pausedLogRows = append(m.pausedLogRows, row)
+				m.pausedLineCount++
+			}
+		}
+		if maxLines := m.config.Log.MaxLines; maxLines > 0 && len(m.pausedLogRows) > maxLines {
+			m.pausedLogRows = m.pausedLogRows[len(m.pausedLogRows)-maxLines:]
+		}
+		m.pausedRerenderJSON = m.pausedRerenderJSON || rerenderJSON
+		m.setLogsFilterPrefix()
+		return
+	}
+
+	// sticky scroll down, i.e. if at bottom already, keep scrolling to bottom as new ones are added
+	scrollDown := m.getCurrentPageModel().ViewportSelectionAtBottom()
+	if rerenderJSON {
+		header, allRows := m.jsonLogs.Render()
+		m.getCurrentPageModel().SetHeader(header)
+		m.getCurrentPageModel().SetAllPageRows(allRows)
+	} else {
+		m.getCurrentPageModel().AppendToViewport(rows, true)
+	}
+	if scrollDown {
+		m.getCurrentPageModel().ScrollViewportToBottom()
+	}
+}
+
+// finishLastLogRow adds the rest of a log line that was split across chunks of logs
+func (m *Model) finishLastLogRow(rest string) {
+	if m.logsPaused && len(m.pausedLogRows) > 0 {
+		m.pausedLogRows[len(m.pausedLogRows)-1].Row += rest
+		return
+	}
+	m.getCurrentPageModel().AppendToViewport([]page.Row{{Row: rest}}, false)
+}
+
+// resumeLogs shows the logs that came in while paused, and follows new ones again
+func (m *Model) resumeLogs() {
+	m.logsPaused = false
+	if m.currentPage == nomad.JobLogsPage {
+		m.showJobLogLines()
+	} else if m.pausedRerenderJSON {
+		header, allRows := m.jsonLogs.Render()
+		m.getCurrentPageModel().SetHeader(header)
+		m.getCurrentPageModel().SetAllPageRows(allRows)
+	} else {
+		m.getCurrentPageModel().AppendToViewport(m.pausedLogRows, true)
+	}
+	m.getCurrentPageModel().SetViewportSelectionToBottom()
+	m.clearLogsPause()
+}
+
+func (m *Model) clearLogsPause() {
+	m.logsPaused = false
+	m.pausedLogRows = nil
+	m.pausedLineCount = 0
+	m.pausedRerenderJSON = false
+}
+
+// setLogsFilterPrefix shows whether the logs are paused, and how many lines came in since
+func (m *Model) setLogsFilterPrefix() {
+	prefix := m.getFilterPrefix(m.currentPage)
+	if !m.logsSince.IsZero() {
+		prefix += fmt.Sprintf(" since %s", formatter.FormatTime(m.logsSince))
+	}
+	if m.logsPaused {
+		prefix += fmt.Sprintf(" (paused, %d new lines)", m.pausedLineCount)
+	}
+	if m.logRecording != nil {
+		prefix += fmt.Sprintf(" (recording to %s)", m.logRecording.Path)
+	}
+	m.getCurrentPageModel().SetFilterPrefix(prefix)
+}
+
+// recordLogs writes logs as they're received to the file they're being recorded to, if any
+func (m *Model) recordLogs(logs string) tea.Cmd {
+	if m.logRecording == nil {
+		return nil
+	}
+	if _, err := m.logRecording.Write([]byte(logs)); err != nil {
+		m.logRecording.Close()
+		m.logRecording = nil
+		m.setLogsFilterPrefix()
+		return m.getCurrentPageModel().ShowToast(fmt.Sprintf("Error recording logs: %s", err), true)
+	}
+	return nil
+}
+
+// stopLogRecording closes the file logs are being recorded to, if any
+func (m *Model) stopLogRecording() tea.Cmd {
+	if m.logRecording == nil {
+		return nil
+	}
+	recording := m.logRecording
+	m.logRecording = nil
+	m.setLogsFilterPrefix()
+	if err := recording.Close(); err != nil {
+		return m.getCurrentPageModel().ShowToast(fmt.Sprintf("Error recording logs: %s", err), true)
+	}
+	return m.getCurrentPageModel().ShowToast(fmt.Sprintf("Recorded logs to %s", recording.Path), false)
+}
+
+// receiveTimeStamp starts the log lines received now, if they're stamped with the time they're received
+func (m Model) receiveTimeStamp() string {
+	if !m.logReceiveTimes {
+		return ""
+	}
+	return nomad.ReceiveTimeStamp(time.Now())
+}
+
+// handleLogsInput acts on the input requested on the logs pages
+func (m *Model) handleLogsInput(input string) tea.Cmd {
+	switch m.logsInput {
+	case jumpToTimeInput:
+		t, err := nomad.ParseLogTime(input, time.Now())
+		if err != nil {
+			return m.getCurrentPageModel().ShowToast(fmt.Sprintf("Error: %s", err), true)
+		}
+		return m.jumpToTime(t)
+	case recordLogsInput:
+		// flushed as logs come in, so the recording can be read while it continues
+		w, err := fileio.NewWriter(input, true)
+		if err != nil {
+			return m.getCurrentPageModel().ShowToast(fmt.Sprintf("Error: %s", err), true)
+		}
+		m.logRecording = w
+		m.setLogsFilterPrefix()
+		return m.getCurrentPageModel().ShowToast(fmt.Sprintf("Recording logs to %s", w.Path), false)
+	case downloadLogsInput:
+		return nomad.DownloadLogs(m.client, m.alloc, m.taskName, m.logType, input)
+	case logsSinceInput:
+		if strings.TrimSpace(input) == "" {
+			m.setLogsSince(time.Time{})
+			return nil
+		}
+		window, err := nomad.ParseLogWindow(input)
+		if err != nil {
+			return m.getCurrentPageModel().ShowToast(fmt.Sprintf("Error: %s", err), true)
+		}
+		m.setLogsSince(time.Now().Add(-window))
+		return nil
+	}
+	if input == "" {
+		return m.getCurrentPageModel().ShowToast("Error: search text is required", true)
+	}
+	m.logSearchQuery = input
+	m.setPage(nomad.LogSearchPage)
+	return m.getCurrentPageCmd()
+}
+
+// jumpToTime selects the first log line at or after t
+func (m *Model) jumpToTime(t time.Time) tea.Cmd {
+	times := m.logTimes
+	atOrAfter := func(row page.Row) bool {
+		rowTime, ok := times.Of(row)
+		return ok && !rowTime.Before(t)
+	}
+	if !m.getCurrentPageModel().SelectFirstRow(atOrAfter) {
+		return m.getCurrentPageModel().ShowToast(fmt.Sprintf("No logs at or after %s", formatter.FormatTime(t)), false)
+	}
+	return nil
+}
+
+// setLogsSince hides the logs before the first line at or after since, showing them all if since is zero. Every line
+// after that one is shown, as logs are in the order they're logged, including lines without a time
+func (m *Model) setLogsSince(since time.Time) {
+	m.logsSince = since
+	var rowsFrom func(page.Row) bool
+	if !since.IsZero() {
+		times := m.logTimes
+		rowsFrom = func(row page.Row) bool {
+			rowTime, ok := times.Of(row)
+			return ok && !rowTime.Before(since)
+		}
+	}
+	m.getCurrentPageModel().SetRowsFrom(rowsFrom)
+	m.getCurrentPageModel().SetViewportSelectionToBottom()
+	m.setLogsFilterPrefix()
+}
+
+// setLogLevelFilter highlights the levels of the logs of a task, and hides the lines below the minimum level,
+// including those without a level
+func (m *Model) setLogLevelFilter() {
+	levels, minLevel := m.logLevels, m.minLogLevel
+	m.getCurrentPageModel().SetRowStyle(func(row page.Row) (lipgloss.Style, bool) {
+		return levels.Of(row).Style()
+	})
+	var rowFilter func(page.Row) bool
+	if minLevel != nomad.AnyLevel {
+		rowFilter = func(row page.Row) bool {
+			return levels.Of(row) >= minLevel
+		}
+	}
+	m.getCurrentPageModel().SetRowFilter(rowFilter)
+}
+
+// selectNextError selects the next error in the logs of a task, or the previous one if forward is false
+func (m *Model) selectNextError(forward bool) tea.Cmd {
+	levels := m.logLevels
+	isError := func(row page.Row) bool {
+		return levels.Of(row) == nomad.ErrorLevel
+	}
+	if !m.getCurrentPageModel().SelectNextRow(isError, forward) {
+		direction := "below"
+		if !forward {
+			direction = "above"
+		}
+		return m.getCurrentPageModel().ShowToast(fmt.Sprintf("No errors %s", direction), false)
+	}
+	return nil
+}
+
+// promptForDownload asks where to save a file in the allocation, defaulting to its name in the working directory
+func (m *Model) promptForDownload(allocFilePath string) tea.Cmd {
+	m.allocFileToDownload = allocFilePath
+	prompt := fmt.Sprintf("Download %s to: ", allocFilePath)
+	return m.getCurrentPageModel().PromptForInput(prompt, path.Base(allocFilePath))
+}
+
+// setNamespace scopes the jobs, tasks and events views to namespace
+func (m *Model) setNamespace(namespace string) {
+	m.config.Namespace = namespace
+	m.config.Event.Namespace = namespace
+	m.client.SetNamespace(namespace)
+	m.header.SetNamespace(namespace)
+}
+
+// newVariableNamespace is the namespace new variables are created in, as variables can't be created in all namespaces
+func (m Model) newVariableNamespace() string {
+	if m.config.Namespace == "" || m.config.Namespace == "*" {
+		return "default"
+	}
+	return m.config.Namespace
+}
+
+func (m *Model) setRevealVariableValues(reveal bool) {
+	m.revealVariableValues = reveal
+	if reveal {
+		keymap.KeyMap.RevealValues.SetHelp("m", "mask values")
+	} else {
+		keymap.KeyMap.RevealValues.SetHelp("m", "reveal values")
+	}
+	m.updateKeyHelp()
+}
+
+// setRegion scopes the views to region. Listing jobs in all regions leaves the other views in the current region
+func (m *Model) setRegion(region string) {
+	m.allRegions = region == nomad.AllRegions
+	if !m.allRegions {
+		m.config.Region = region
+		m.client.SetRegion(region)
+	}
+	m.header.SetRegion(m.currentRegion())
+}
+
+func (m Model) currentRegion() string {
+	if m.allRegions {
+		return nomad.AllRegions
+	}
+	return m.config.Region
+}
+
+// selectJob sets the job to view from the key of a row in the jobs page
+func (m *Model) selectJob(jobsKey string) {
+	m.jobID, m.jobNamespace = nomad.JobIDAndNamespaceFromKey(jobsKey)
+	m.client = m.jobClient(jobsKey)
+}
+
+// jobClient is the client for the job from the key of a row in the jobs page, which may be in another region when
+// listing jobs in all regions
+func (m Model) jobClient(jobsKey string) api.Client {
+	client := m.client
+	if region := nomad.JobRegionFromKey(jobsKey); m.allRegions && region != "" {
+		client.SetRegion(region)
+	}
+	return client
+}
+
+// setProfile connects to the cluster in profile, starting over from the root page of the current mode
+func (m *Model) setProfile(profile Profile) tea.Cmd {
+	m.config = m.config.WithProfile(profile)
+	client, err := m.config.client()
+	if err != nil {
+		m.err = err
+		return nil
+	}
+	m.client = *client
+
+	m.header.SetProfile(profile.Name, profile.Color)
+	m.header.SetNamespace(m.config.Namespace)
+	m.allRegions = false
+	m.header.SetRegion(m.config.Region)
+	m.expandedJobs = make(map[string]bool)
+	m.updateID = nextUpdateID()
+
+	m.initializePageModels(nomad.Unset)
+	if m.compact {
+		for _, pm := range m.pageModels {
+			pm.ToggleCompact()
+		}
+		m.setPageWindowSize()
+	}
+	m.setPage(nomad.ModeRootPage(m.mode))
+	return m.getCurrentPageCmd()
+}
+
+func (m *Model) setMode(mode nomad.Mode, rootPage nomad.Page) tea.Cmd {
+	m.setPage(rootPage)
+	m.mode = mode
+	return m.getCurrentPageCmd()
+}
+
 func (m *Model) getCurrentPageModel() *page.Model {
 	return m.pageModels[m.currentPage]
 }
@@ -643,7 +1838,7 @@ func (m *Model) setInPty(inPty bool) {
 }
 
 func (m *Model) updateKeyHelp() {
-	newKeyHelp := nomad.GetPageKeyHelp(m.currentPage, m.currentPageFilterFocused(), m.currentPageFilterApplied(), m.currentPageViewportSaving(), m.getCurrentPageModel().EnteringInput(), m.inPty, m.webSocketConnected, m.logType, m.compact, m.inJobsMode)
+	newKeyHelp := nomad.GetPageKeyHelp(m.currentPage, m.currentPageFilterFocused(), m.currentPageFilterApplied(), m.currentPageViewportSaving(), m.getCurrentPageModel().EnteringInput(), m.getCurrentPageModel().Confirming(), m.inPty, m.webSocketConnected, m.logType, m.compact, m.mode)
 	m.header.SetKeyHelp(newKeyHelp)
 }
 
@@ -660,7 +1855,7 @@ func (m *Model) toggleCompact() {
 func (m Model) getCurrentPageCmd() tea.Cmd {
 	switch m.currentPage {
 	case nomad.JobsPage:
-		return nomad.FetchJobs(m.client, m.config.JobColumns)
+		return nomad.FetchJobs(m.client, m.config.JobColumns, m.expandedJobs, m.allRegions)
 	case nomad.AllTasksPage:
 		return nomad.FetchAllTasks(m.client, m.config.AllTaskColumns)
 	case nomad.JobSpecPage:
@@ -686,11 +1881,68 @@ func (m Model) getCurrentPageCmd() tea.Cmd {
 	case nomad.AllocSpecPage:
 		return nomad.FetchAllocSpec(m.client, m.alloc.ID)
 	case nomad.LogsPage:
-		return nomad.FetchLogs(m.client, m.alloc, m.taskName, m.logType, m.config.Log.Offset, m.config.Log.Tail)
+		var jsonQuery *gojq.Code
+		if m.showJSONLogs {
+			jsonQuery = m.config.Log.jsonQuery(m.alloc.JobID)
+		}
+		return nomad.FetchLogs(m.client, m.alloc, m.taskName, m.logType, m.config.Log.Offset, m.config.Log.Tail, jsonQuery)
 	case nomad.LoglinePage:
 		return nomad.PrettifyLine(m.logline, nomad.LoglinePage)
+	case nomad.LogSearchPage:
+		search := nomad.LogSearch{ID: nextUpdateID(), Alloc: m.alloc, TaskName: m.taskName, LogType: m.logType, Query: m.logSearchQuery}
+		return nomad.StartLogSearch(m.client, search)
+	case nomad.JobLogsPage:
+		return nomad.FetchJobLogs(m.client, m.jobID, m.jobNamespace, m.jobLogsTaskGroup, m.logType, m.config.Log.Offset, m.config.Log.Tail)
+	case nomad.JobLogAllocsPage:
+		return nomad.FetchJobLogAllocs(m.jobLogsStream.Allocs, m.hiddenJobLogAllocs)
+	case nomad.AllocFilesPage:
+		return nomad.FetchAllocFiles(m.client, m.alloc, m.allocFilePath)
+	case nomad.AllocFilePage:
+		return nomad.FetchAllocFile(m.client, m.alloc, m.allocFilePath)
 	case nomad.StatsPage:
 		return nomad.FetchStats(m.client, m.alloc.ID, m.alloc.Name)
+	case nomad.NodesPage:
+		return nomad.FetchNodes(m.client, m.config.NodeColumns)
+	case nomad.NodeTasksPage:
+		return nomad.FetchTasksForNode(m.client, m.nodeID, m.config.NodeTaskColumns)
+	case nomad.NodeSpecPage:
+		return nomad.FetchNodeSpec(m.client, m.nodeID)
+	case nomad.JobDeploymentsPage:
+		return nomad.FetchJobDeployments(m.client, m.jobID, m.jobNamespace)
+	case nomad.AllDeploymentsPage:
+		return nomad.FetchAllDeployments(m.client)
+	case nomad.JobEvaluationsPage:
+		return nomad.FetchJobEvaluations(m.client, m.jobID, m.jobNamespace)
+	case nomad.JobEvaluationPage:
+		return nomad.FetchEvaluation(m.client, m.evalID, m.evalNamespace)
+	case nomad.JobVersionsPage:
+		return nomad.FetchJobVersions(m.client, m.jobID, m.jobNamespace)
+	case nomad.JobVersionDiffPage:
+		return nomad.FetchJobVersionDiff(m.client, m.jobID, m.jobNamespace, m.diffFromVersion, m.diffToVersion)
+	case nomad.SignalPage:
+		return nomad.FetchSignals()
+	case nomad.NamespacesPage:
+		return nomad.FetchNamespaces(m.client)
+	case nomad.ProfilesPage:
+		return nomad.FetchProfiles(m.config.profileInfos())
+	case nomad.RegionsPage:
+		return nomad.FetchRegions(m.client)
+	case nomad.VolumesPage:
+		return nomad.FetchCSIVolumes(m.client)
+	case nomad.VolumePage:
+		return nomad.FetchVolumeClaims(m.client, m.volumeID, m.volumeNamespace)
+	case nomad.PluginsPage:
+		return nomad.FetchCSIPlugins(m.client)
+	case nomad.HostVolumesPage:
+		return nomad.FetchHostVolumes(m.client)
+	case nomad.ServicesPage:
+		return nomad.FetchServices(m.client)
+	case nomad.ServicePage:
+		return nomad.FetchServiceRegistrations(m.client, m.serviceName, m.serviceNamespace)
+	case nomad.VariablesPage:
+		return nomad.FetchVariables(m.client)
+	case nomad.VariablePage:
+		return nomad.FetchVariable(m.client, m.variablePath, m.variableNamespace, m.revealVariableValues)
 	default:
 		panic("page load command not found")
 	}
@@ -717,5 +1969,27 @@ func (m Model) currentPageViewportSaving() bool {
 }
 
 func (m Model) getFilterPrefix(page nomad.Page) string {
-	return page.GetFilterPrefix(m.config.Namespace, m.jobID, m.taskName, m.alloc.Name, m.alloc.ID, m.config.Event.Topics, m.config.Event.Namespace)
+	return page.GetFilterPrefix(nomad.FilterPrefixInfo{
+		Namespace:         m.config.Namespace,
+		Profile:           m.config.Profile.Name,
+		Region:            m.currentRegion(),
+		JobID:             m.jobID,
+		TaskGroup:         m.jobLogsTaskGroup,
+		TaskName:          m.taskName,
+		AllocName:         m.alloc.Name,
+		AllocID:           m.alloc.ID,
+		NodeName:          m.nodeName,
+		EvalID:            m.evalID,
+		VariablePath:      m.variablePath,
+		VariableNamespace: m.variableNamespace,
+		ServiceName:       m.serviceName,
+		VolumeID:          m.volumeID,
+		AllocFilePath:     m.allocFilePath,
+		MarkedJobVersion:  m.markedJobVersion,
+		DiffFromVersion:   m.diffFromVersion,
+		DiffToVersion:     m.diffToVersion,
+		MinLogLevel:       m.minLogLevel,
+		EventTopics:       m.config.Event.Topics,
+		EventNamespace:    m.config.Event.Namespace,
+	})
 }
@@ -2,6 +2,7 @@ package app
 
 import (
 	"github.com/hashicorp/nomad/api"
+	"github.com/robinovitch61/wander/internal/tui/nomad"
 	"strings"
 	"sync"
 )
@@ -18,6 +19,29 @@ func nextUpdateID() int {
 	return updateID
 }
 
+// WithProfile connects to the cluster in p, with its settings
+func (c Config) WithProfile(p Profile) Config {
+	c.Profile = p
+	c.URL, c.Token, c.Region, c.Namespace = p.URL, p.Token, p.Region, p.Namespace
+	c.Event.Namespace = p.EventNamespace
+	c.HTTPAuth = p.HTTPAuth
+	c.TLS = p.TLS
+	c.JobColumns = p.JobColumns
+	c.AllTaskColumns = p.AllTaskColumns
+	c.JobTaskColumns = p.JobTaskColumns
+	c.NodeColumns = p.NodeColumns
+	c.NodeTaskColumns = p.NodeTaskColumns
+	return c
+}
+
+func (c Config) profileInfos() []nomad.ProfileInfo {
+	var infos []nomad.ProfileInfo
+	for _, p := range c.Profiles {
+		infos = append(infos, nomad.ProfileInfo{Name: p.Name, Address: p.URL, Region: p.Region, Namespace: p.Namespace})
+	}
+	return infos
+}
+
 func (c Config) client() (*api.Client, error) {
 	config := &api.Config{
 		Address:   c.URL,
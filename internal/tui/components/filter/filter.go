@@ -106,6 +106,10 @@ func (m *Model) SetPrefix(prefix string) {
 	m.prefix = prefix
 }
 
+func (m *Model) SetValue(value string) {
+	m.textinput.SetValue(value)
+}
+
 func (m *Model) SetSuffix(suffix string) {
 	m.suffix = suffix
 }
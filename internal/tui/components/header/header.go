@@ -2,11 +2,13 @@ package header
 
 import (
 	"github.com/charmbracelet/lipgloss"
+	"github.com/robinovitch61/wander/internal/tui/nomad"
 	"github.com/robinovitch61/wander/internal/tui/style"
 )
 
 type Model struct {
 	logo, logoColor, nomadUrl, version, keyHelp string
+	namespace, region, profile, profileColor    string
 	compact                                     bool
 }
 
@@ -20,6 +22,7 @@ func (m Model) View() string {
 		logoStyle.Foreground(lipgloss.Color(m.logoColor))
 	}
 	clusterUrl := style.ClusterUrl.Render(m.nomadUrl)
+	context := m.contextView()
 	if m.compact {
 		return lipgloss.JoinHorizontal(
 			lipgloss.Center,
@@ -27,14 +30,37 @@ func (m Model) View() string {
 			style.KeyHelp.Render(m.keyHelp),
 			style.Regular.Copy().Padding(0, 2, 0, 0).Render(m.version),
 			clusterUrl,
+			style.Regular.Copy().Padding(0, 0, 0, 2).Render(context),
 		)
 	}
 	logo := logoStyle.Render(m.logo)
-	left := style.Header.Render(lipgloss.JoinVertical(lipgloss.Center, logo, m.version, clusterUrl))
+	left := style.Header.Render(lipgloss.JoinVertical(lipgloss.Center, logo, m.version, clusterUrl, context))
 	styledKeyHelp := style.KeyHelp.Render(m.keyHelp)
 	return lipgloss.JoinHorizontal(lipgloss.Center, left, styledKeyHelp)
 }
 
+// contextView shows what the cluster views are scoped to
+func (m Model) contextView() string {
+	namespace := m.namespace
+	if namespace == "*" || namespace == "" {
+		namespace = "all"
+	}
+	context := "namespace " + style.Bold.Render(namespace)
+	if m.region == nomad.AllRegions {
+		context += ", jobs in " + style.Bold.Render("all regions")
+	} else if m.region != "" {
+		context += ", region " + style.Bold.Render(m.region)
+	}
+	if m.profile != "" {
+		profileStyle := style.Bold.Copy()
+		if m.profileColor != "" {
+			profileStyle.Foreground(lipgloss.Color(m.profileColor))
+		}
+		context = profileStyle.Render(m.profile) + " " + context
+	}
+	return context
+}
+
 func (m Model) ViewHeight() int {
 	return lipgloss.Height(m.View())
 }
@@ -43,6 +69,18 @@ func (m *Model) SetKeyHelp(keyHelp string) {
 	m.keyHelp = keyHelp
 }
 
+func (m *Model) SetNamespace(namespace string) {
+	m.namespace = namespace
+}
+
+func (m *Model) SetRegion(region string) {
+	m.region = region
+}
+
+func (m *Model) SetProfile(profile, profileColor string) {
+	m.profile, m.profileColor = profile, profileColor
+}
+
 func (m *Model) ToggleCompact() {
 	m.compact = !m.compact
 }
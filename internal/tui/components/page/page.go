@@ -14,6 +14,7 @@ import (
 	"github.com/robinovitch61/wander/internal/tui/constants"
 	"github.com/robinovitch61/wander/internal/tui/keymap"
 	"github.com/robinovitch61/wander/internal/tui/message"
+	"github.com/robinovitch61/wander/internal/tui/style"
 	"strings"
 )
 
@@ -23,6 +24,7 @@ type Config struct {
 	SelectionEnabled, WrapText, RequestInput bool
 	CompactTableContent                      bool
 	ViewportConditionalStyle                 map[string]lipgloss.Style
+	ViewportPrefixStyle                      map[string]lipgloss.Style
 }
 
 type Model struct {
@@ -44,9 +46,27 @@ type Model struct {
 	inputPrefix      string
 	initialized      bool
 
+	// promptingForInput is true when input is requested on demand by an action, e.g. scaling a task group,
+	// rather than by a page that always requests input
+	promptingForInput bool
+
+	// confirmationPrompt is shown in place of the filter until the user confirms or cancels onConfirm
+	confirmationPrompt string
+	onConfirm          tea.Cmd
+
 	// if FilterWithContext is true, filtering doesn't remove rows, just highlights the matching text
 	// and makes it so you can cycle through matches
 	FilterWithContext bool
+
+	// rowFilter hides rows it returns false for, before any filtering by text
+	rowFilter func(Row) bool
+	// rowsFrom hides the rows before the first one it returns true for, before rowFilter
+	rowsFrom func(Row) bool
+	// rowStyle styles rows it returns true for
+	rowStyle func(Row) (lipgloss.Style, bool)
+
+	// maxRows is the most rows kept when appending rows, evicting the oldest ones. 0 for no limit
+	maxRows int
 }
 
 func New(c Config, copySavePath, startFiltering, filterWithContext bool) Model {
@@ -58,6 +78,7 @@ func New(c Config, copySavePath, startFiltering, filterWithContext bool) Model {
 	pageViewport.SetSelectionEnabled(c.SelectionEnabled)
 	pageViewport.SetWrapText(c.WrapText)
 	pageViewport.ConditionalStyle = c.ViewportConditionalStyle
+	pageViewport.PrefixStyle = c.ViewportPrefixStyle
 
 	needsNewInput := false
 	var pageTextInput textinput.Model
@@ -93,16 +114,22 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 	)
 
 	if m.EnteringInput() {
-		if !m.initialized {
+		if !m.initialized && !m.promptingForInput {
 			m.initialized = true
 			return m, textinput.Blink
 		} else {
 			switch msg := msg.(type) {
 			case tea.KeyMsg:
-				if msg.String() == "enter" && len(m.textinput.Value()) > 0 {
+				// input prompted for by an action may legitimately be empty, e.g. dispatching a job without meta
+				if msg.String() == "enter" && (len(m.textinput.Value()) > 0 || m.promptingForInput) {
 					m.needsNewInput = false
+					m.promptingForInput = false
 					return m, func() tea.Msg { return message.PageInputReceivedMsg{Input: m.textinput.Value()} }
 				}
+				if m.promptingForInput && key.Matches(msg, keymap.KeyMap.Back) {
+					m.promptingForInput = false
+					return m, nil
+				}
 			}
 
 			m.textinput, cmd = m.textinput.Update(msg)
@@ -110,6 +137,20 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 		}
 	}
 
+	if m.Confirming() {
+		if msg, ok := msg.(tea.KeyMsg); ok {
+			switch {
+			case key.Matches(msg, keymap.KeyMap.Confirm):
+				cmd = m.onConfirm
+				m.clearConfirmation()
+				return m, cmd
+			case key.Matches(msg, keymap.KeyMap.Back), key.Matches(msg, keymap.KeyMap.Deny):
+				m.clearConfirmation()
+			}
+			return m, nil
+		}
+	}
+
 	if m.viewport.Saving() {
 		m.viewport, cmd = m.viewport.Update(msg)
 		cmds = append(cmds, cmd)
@@ -204,7 +245,11 @@ func (m Model) View() string {
 			content = m.viewport.View()
 		}
 	}
-	return lipgloss.JoinVertical(lipgloss.Left, m.filter.View(), content)
+	top := m.filter.View()
+	if m.Confirming() {
+		top = m.confirmationView()
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, top, content)
 }
 
 func (m *Model) SetWindowSize(width, height int) {
@@ -225,6 +270,35 @@ func (m *Model) SetViewportStyle(headerStyle, contentStyle lipgloss.Style) {
 	m.viewport.ContentStyle = contentStyle
 }
 
+// SetViewportPrefixStyle styles rows starting with each key with its style, replacing any previous styles
+func (m *Model) SetViewportPrefixStyle(prefixStyle map[string]lipgloss.Style) {
+	m.viewport.PrefixStyle = prefixStyle
+}
+
+// SetMaxRows limits the rows kept when appending rows, evicting the oldest ones. 0 for no limit
+func (m *Model) SetMaxRows(maxRows int) {
+	m.maxRows = maxRows
+}
+
+// SetRowFilter hides rows that rowFilter returns false for. A nil rowFilter shows every row
+func (m *Model) SetRowFilter(rowFilter func(Row) bool) {
+	m.rowFilter = rowFilter
+	m.updateViewport()
+}
+
+// SetRowsFrom hides the rows before the first one that rowsFrom returns true for, e.g. to show the latest logs only.
+// A nil rowsFrom shows every row
+func (m *Model) SetRowsFrom(rowsFrom func(Row) bool) {
+	m.rowsFrom = rowsFrom
+	m.updateViewport()
+}
+
+// SetRowStyle styles rows that rowStyle returns true for. A nil rowStyle leaves every row in the viewport style
+func (m *Model) SetRowStyle(rowStyle func(Row) (lipgloss.Style, bool)) {
+	m.rowStyle = rowStyle
+	m.updateViewport()
+}
+
 func (m *Model) SetLoading(isLoading bool) {
 	m.loading = isLoading
 }
@@ -238,10 +312,46 @@ func (m *Model) SetFilterPrefix(prefix string) {
 	m.filter.SetPrefix(prefix)
 }
 
+// SetFilter applies filter as if the user had typed it in
+func (m *Model) SetFilter(filter string) {
+	m.filter.SetValue(filter)
+	m.filter.Blur()
+	m.updateViewport()
+	m.ResetContextFilter()
+}
+
 func (m *Model) SetViewportSelectionToBottom() {
 	m.viewport.SetSelectedContentIdx(len(m.pageData.FilteredRows) - 1)
 }
 
+// SelectNextRow selects the closest row after the selected one that matches, or before it if forward is false.
+// Returns false if there isn't one
+func (m *Model) SelectNextRow(matches func(Row) bool, forward bool) bool {
+	step := 1
+	if !forward {
+		step = -1
+	}
+	rows := m.pageData.FilteredRows
+	for i := m.viewport.SelectedContentIdx() + step; i >= 0 && i < len(rows); i += step {
+		if matches(rows[i]) {
+			m.viewport.SetSelectedContentIdx(i)
+			return true
+		}
+	}
+	return false
+}
+
+// SelectFirstRow selects the first row that matches. Returns false if there isn't one
+func (m *Model) SelectFirstRow(matches func(Row) bool) bool {
+	for i, row := range m.pageData.FilteredRows {
+		if matches(row) {
+			m.viewport.SetSelectedContentIdx(i)
+			return true
+		}
+	}
+	return false
+}
+
 func (m *Model) ScrollViewportToBottom() {
 	m.viewport.ScrollToBottom()
 }
@@ -254,6 +364,27 @@ func (m *Model) HideToast() {
 	m.viewport.HideToast()
 }
 
+func (m *Model) ShowToast(message string, isError bool) tea.Cmd {
+	return m.viewport.ShowToast(message, isError)
+}
+
+// RequestConfirmation prompts the user to confirm an action before the onConfirm command is run
+func (m *Model) RequestConfirmation(prompt string, onConfirm tea.Cmd) {
+	m.confirmationPrompt = prompt
+	m.onConfirm = onConfirm
+}
+
+// PromptForInput requests input in place of the page content, which is sent in a message.PageInputReceivedMsg
+func (m *Model) PromptForInput(prefix, initialValue string) tea.Cmd {
+	m.textinput = textinput.New()
+	m.textinput.Focus()
+	m.textinput.Prompt = ""
+	m.textinput.SetValue(initialValue)
+	m.inputPrefix = prefix
+	m.promptingForInput = true
+	return textinput.Blink
+}
+
 func (m *Model) AppendToViewport(rows []Row, startOnNewLine bool) {
 	newPageRows := m.pageData.AllRows
 	for i, r := range rows {
@@ -271,7 +402,17 @@ func (m *Model) AppendToViewport(rows []Row, startOnNewLine bool) {
 			}
 		}
 	}
-	m.SetAllPageRows(newPageRows)
+
+	if m.maxRows <= 0 || len(newPageRows) <= m.maxRows {
+		m.SetAllPageRows(newPageRows)
+		return
+	}
+
+	// keep the same row selected, or the oldest one if it was evicted
+	numShownBefore := m.numShown(newPageRows)
+	m.SetAllPageRows(newPageRows[len(newPageRows)-m.maxRows:])
+	numEvictedShown := numShownBefore - len(m.pageData.FilteredRows)
+	m.viewport.SetSelectedContentIdx(max(0, m.viewport.SelectedContentIdx()-numEvictedShown))
 }
 
 func (m *Model) SetDoesNeedNewInput() {
@@ -335,8 +476,16 @@ func (m Model) ViewportSelectionAtBottom() bool {
 	return m.viewport.SelectedContentIdx() == len(m.pageData.FilteredRows)-1
 }
 
+func (m Model) Confirming() bool {
+	return m.onConfirm != nil
+}
+
 func (m Model) EnteringInput() bool {
-	return m.doesRequestInput && m.needsNewInput
+	return (m.doesRequestInput && m.needsNewInput) || m.promptingForInput
+}
+
+func (m Model) PromptingForInput() bool {
+	return m.promptingForInput
 }
 
 func (m Model) FilterFocused() bool {
@@ -355,6 +504,22 @@ func (m Model) ViewportHeight() int {
 	return lipgloss.Height(m.viewport.View())
 }
 
+func (m *Model) clearConfirmation() {
+	m.confirmationPrompt = ""
+	m.onConfirm = nil
+}
+
+func (m Model) confirmationView() string {
+	prompt := fmt.Sprintf(
+		"%s (%s/%s)",
+		m.confirmationPrompt,
+		keymap.KeyMap.Confirm.Help().Key,
+		keymap.KeyMap.Deny.Help().Key,
+	)
+	rendered := style.ConfirmationPrompt.Copy().Width(m.width).Render(prompt)
+	return lipgloss.PlaceVertical(m.filter.ViewHeight(), lipgloss.Center, rendered)
+}
+
 func (m *Model) clearFilter() {
 	m.filter.BlurAndClear()
 	m.setIndexesOfFilteredRows([]int{})
@@ -364,17 +529,20 @@ func (m *Model) clearFilter() {
 func (m *Model) updateViewport() {
 	m.viewport.SetStringToHighlight(m.filter.Value())
 	m.updateFilteredData()
+	m.viewport.ContentStyles = m.getContentStyles()
 	m.viewport.SetContent(rowsToStrings(m.pageData.FilteredRows))
 }
 
 func (m *Model) updateFilteredData() {
+	rows := m.hideRows(m.pageData.AllRows)
+
 	if !m.filter.HasFilterText() {
-		m.pageData.FilteredRows = m.pageData.AllRows
+		m.pageData.FilteredRows = rows
 		m.setIndexesOfFilteredRows([]int{})
 	} else if m.FilterWithContext {
-		m.pageData.FilteredRows = m.pageData.AllRows
+		m.pageData.FilteredRows = rows
 		var indexesOfFilteredRows []int
-		for i, entry := range m.pageData.AllRows {
+		for i, entry := range rows {
 			if strings.Contains(entry.Row, m.filter.Value()) {
 				indexesOfFilteredRows = append(indexesOfFilteredRows, i)
 			}
@@ -382,7 +550,7 @@ func (m *Model) updateFilteredData() {
 		m.setIndexesOfFilteredRows(indexesOfFilteredRows)
 	} else {
 		var filteredData []Row
-		for _, entry := range m.pageData.AllRows {
+		for _, entry := range rows {
 			if strings.Contains(entry.Row, m.filter.Value()) {
 				filteredData = append(filteredData, entry)
 			}
@@ -391,6 +559,58 @@ func (m *Model) updateFilteredData() {
 	}
 }
 
+// hideRows removes the rows hidden by rowsFrom and rowFilter
+func (m Model) hideRows(rows []Row) []Row {
+	if m.rowsFrom != nil {
+		from := len(rows)
+		for i, entry := range rows {
+			if m.rowsFrom(entry) {
+				from = i
+				break
+			}
+		}
+		rows = rows[from:]
+	}
+	if m.rowFilter == nil {
+		return rows
+	}
+	var shown []Row
+	for _, entry := range rows {
+		if m.rowFilter(entry) {
+			shown = append(shown, entry)
+		}
+	}
+	return shown
+}
+
+// numShown is how many of rows would be in the viewport content rather than filtered out
+func (m Model) numShown(rows []Row) int {
+	rows = m.hideRows(rows)
+	if m.FilterWithContext || !m.filter.HasFilterText() {
+		return len(rows)
+	}
+	n := 0
+	for _, entry := range rows {
+		if strings.Contains(entry.Row, m.filter.Value()) {
+			n++
+		}
+	}
+	return n
+}
+
+func (m Model) getContentStyles() map[int]lipgloss.Style {
+	if m.rowStyle == nil {
+		return nil
+	}
+	styles := make(map[int]lipgloss.Style)
+	for i, entry := range m.pageData.FilteredRows {
+		if s, ok := m.rowStyle(entry); ok {
+			styles[i] = s
+		}
+	}
+	return styles
+}
+
 func (m *Model) updateFilter() {
 	if !m.FilterWithContext {
 		return
@@ -78,6 +78,10 @@ type Model struct {
 	FooterStyle           lipgloss.Style
 	// ConditionalStyle styles lines containing key with corresponding style in value
 	ConditionalStyle map[string]lipgloss.Style
+	// PrefixStyle styles lines starting with key with corresponding style in value
+	PrefixStyle map[string]lipgloss.Style
+	// ContentStyles styles lines by their index in the content, over ConditionalStyle and PrefixStyle
+	ContentStyles map[int]lipgloss.Style
 }
 
 func New(width, height int, compactTableContent bool) (m Model) {
@@ -136,11 +140,9 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 		switch msg := msg.(type) {
 		case SaveStatusMsg:
 			if msg.Err != "" {
-				m.toast = toast.New(fmt.Sprintf("Error: %s", msg.Err))
-				m.toast.MessageStyle = style.ErrorToast.Copy().Width(m.width)
+				cmds = append(cmds, m.ShowToast(fmt.Sprintf("Error: %s", msg.Err), true))
 			} else {
-				m.toast = toast.New(msg.SuccessMessage)
-				m.toast.MessageStyle = style.SuccessToast.Copy().Width(m.width)
+				cmds = append(cmds, m.ShowToast(msg.SuccessMessage, false))
 			}
 
 		case tea.KeyMsg:
@@ -296,6 +298,14 @@ func (m Model) View() string {
 				lineStyle = v
 			}
 		}
+		for k, v := range m.PrefixStyle {
+			if strings.HasPrefix(m.content[contentIdx], k) {
+				lineStyle = v
+			}
+		}
+		if v, exists := m.ContentStyles[contentIdx]; exists {
+			lineStyle = v
+		}
 		if isSelected {
 			lineStyle = m.SelectedContentStyle
 		}
@@ -358,6 +368,19 @@ func (m *Model) HideToast() {
 	m.toast.Visible = false
 }
 
+// ShowToast shows a message at the bottom of the viewport until it times out
+func (m *Model) ShowToast(message string, isError bool) tea.Cmd {
+	var cmd tea.Cmd
+	m.toast = toast.New(message)
+	if isError {
+		m.toast.MessageStyle = style.ErrorToast.Copy().Width(m.width)
+	} else {
+		m.toast.MessageStyle = style.SuccessToast.Copy().Width(m.width)
+	}
+	m.toast, cmd = m.toast.Update(nil)
+	return cmd
+}
+
 // SetSize sets the viewport's width and height, including header.
 func (m *Model) SetSize(width, height int) {
 	m.setWidthAndHeight(width, height)
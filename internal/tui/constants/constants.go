@@ -31,6 +31,30 @@ var JobsTableStatusStyles = map[string]lipgloss.Style{
 
 var TasksTableStatusStyles = JobsTableStatusStyles
 
+var NodesTableStatusStyles = map[string]lipgloss.Style{
+	TablePadding + "initializing" + TablePadding: style.JobRowPending,
+	TablePadding + "down" + TablePadding:         style.JobRowDead,
+}
+
+var DeploymentsTableStatusStyles = map[string]lipgloss.Style{
+	TablePadding + "paused" + TablePadding:  style.JobRowPending,
+	TablePadding + "pending" + TablePadding: style.JobRowPending,
+	TablePadding + "blocked" + TablePadding: style.JobRowPending,
+	TablePadding + "failed" + TablePadding:  style.JobRowDead,
+}
+
+var EvaluationsTableStatusStyles = map[string]lipgloss.Style{
+	TablePadding + "pending" + TablePadding: style.JobRowPending,
+	TablePadding + "blocked" + TablePadding: style.JobRowPending,
+	TablePadding + "failed" + TablePadding:  style.JobRowDead,
+}
+
+var JobVersionDiffStyles = map[string]lipgloss.Style{
+	"+ ": style.DiffAdded,
+	"- ": style.DiffDeleted,
+	"~ ": style.DiffEdited,
+}
+
 const DefaultPageInput = "/bin/sh"
 
 // DefaultEventJQQuery is a single line as this shows up verbatim in `wander --help`
@@ -38,3 +62,6 @@ const DefaultEventJQQuery = `.Events[] | {"1:Index": .Index, "2:Topic": .Topic,
 
 // DefaultAllocEventJQQuery is a single line as this shows up verbatim in `wander --help`
 const DefaultAllocEventJQQuery = `.Index as $index | .Events[] | .Type as $type | .Payload.Allocation | .DeploymentStatus.Healthy as $healthy | .ClientStatus as $clientStatus | .Name as $allocName | (.TaskStates // {"":{"Events": [{}]}}) | to_entries[] | .key as $k | .value.Events[] | {"0:Index": $index, "1:AllocName": $allocName, "2:TaskName": $k, "3:Type": $type, "4:Time": ((.Time // 0) / 1000000000 | todate), "5:Msg": .DisplayMessage, "6:Healthy": $healthy, "7:ClientStatus": $clientStatus}`
+
+// DefaultLogJSONQuery is a single line as this shows up verbatim in `wander --help`
+const DefaultLogJSONQuery = `{"1:Time": (.time // .ts // .timestamp // .["@timestamp"]), "2:Level": (.level // .lvl // .severity), "3:Msg": (.msg // .message), "4:Fields": (del(.time, .ts, .timestamp, .["@timestamp"], .level, .lvl, .severity, .msg, .message) | to_entries | map("\(.key)=\(.value | tostring)") | join(" "))}`
@@ -11,6 +11,9 @@ type keyMap struct {
 	Compact         key.Binding
 	JobsMode        key.Binding
 	TasksMode       key.Binding
+	NodesMode       key.Binding
+	ServicesMode    key.Binding
+	VolumesMode     key.Binding
 	JobEvents       key.Binding
 	JobMeta         key.Binding
 	AllocEvents     key.Binding
@@ -23,8 +26,56 @@ type keyMap struct {
 	Stats           key.Binding
 	StdOut          key.Binding
 	StdErr          key.Binding
+	StdOutAndStdErr key.Binding
+	PauseLogs       key.Binding
+	ReceiveTimes    key.Binding
+	JumpToTime      key.Binding
+	LogsSince       key.Binding
+	RecordLogs      key.Binding
+	SearchLogs      key.Binding
+	AllocFiles      key.Binding
+	Download        key.Binding
+	JobLogs         key.Binding
+	TaskGroupLogs   key.Binding
+	JobLogAllocs    key.Binding
+	JSONLogs        key.Binding
+	MinLogLevel     key.Binding
+	NextError       key.Binding
+	PrevError       key.Binding
 	Spec            key.Binding
 	Wrap            key.Binding
+	Confirm         key.Binding
+	Deny            key.Binding
+	JobDeployments  key.Binding
+	AllDeployments  key.Binding
+	Promote         key.Binding
+	Fail            key.Binding
+	PauseResume     key.Binding
+	JobEvaluations  key.Binding
+	JobVersions     key.Binding
+	MarkVersion     key.Binding
+	Revert          key.Binding
+	StopJob         key.Binding
+	PurgeJob        key.Binding
+	StartJob        key.Binding
+	RestartJob      key.Binding
+	Scale           key.Binding
+	RestartTask     key.Binding
+	RestartAlloc    key.Binding
+	StopAlloc       key.Binding
+	Signal          key.Binding
+	LaunchJob       key.Binding
+	ExpandJob       key.Binding
+	Namespaces      key.Binding
+	Profiles        key.Binding
+	Regions         key.Binding
+	Variables       key.Binding
+	NewVariable     key.Binding
+	EditVariable    key.Binding
+	DeleteVariable  key.Binding
+	RevealValues    key.Binding
+	Plugins         key.Binding
+	HostVolumes     key.Binding
 }
 
 var KeyMap = keyMap{
@@ -52,6 +103,18 @@ var KeyMap = keyMap{
 		key.WithKeys("A"),
 		key.WithHelp("A", "all tasks"),
 	),
+	NodesMode: key.NewBinding(
+		key.WithKeys("C"),
+		key.WithHelp("C", "nodes"),
+	),
+	ServicesMode: key.NewBinding(
+		key.WithKeys("I"),
+		key.WithHelp("I", "services"),
+	),
+	VolumesMode: key.NewBinding(
+		key.WithKeys("O"),
+		key.WithHelp("O", "volumes"),
+	),
 	JobEvents: key.NewBinding(
 		key.WithKeys("v"),
 		key.WithHelp("v", "events"),
@@ -100,6 +163,70 @@ var KeyMap = keyMap{
 		key.WithKeys("e"),
 		key.WithHelp("e", "stderr"),
 	),
+	StdOutAndStdErr: key.NewBinding(
+		key.WithKeys("B"),
+		key.WithHelp("B", "stdout+stderr"),
+	),
+	PauseLogs: key.NewBinding(
+		key.WithKeys("p"),
+		key.WithHelp("p", "pause/resume"),
+	),
+	ReceiveTimes: key.NewBinding(
+		key.WithKeys("z"),
+		key.WithHelp("z", "toggle receive times"),
+	),
+	JumpToTime: key.NewBinding(
+		key.WithKeys("T"),
+		key.WithHelp("T", "jump to time"),
+	),
+	LogsSince: key.NewBinding(
+		key.WithKeys("W"),
+		key.WithHelp("W", "last n minutes"),
+	),
+	RecordLogs: key.NewBinding(
+		key.WithKeys("ctrl+t"),
+		key.WithHelp("ctrl+t", "record to file"),
+	),
+	SearchLogs: key.NewBinding(
+		key.WithKeys("L"),
+		key.WithHelp("L", "search all logs"),
+	),
+	AllocFiles: key.NewBinding(
+		key.WithKeys("a"),
+		key.WithHelp("a", "alloc files"),
+	),
+	Download: key.NewBinding(
+		key.WithKeys("w"),
+		key.WithHelp("w", "download"),
+	),
+	JobLogs: key.NewBinding(
+		key.WithKeys("ctrl+l"),
+		key.WithHelp("ctrl+l", "job logs"),
+	),
+	TaskGroupLogs: key.NewBinding(
+		key.WithKeys("L"),
+		key.WithHelp("L", "group logs"),
+	),
+	JobLogAllocs: key.NewBinding(
+		key.WithKeys("t"),
+		key.WithHelp("t", "toggle allocs"),
+	),
+	JSONLogs: key.NewBinding(
+		key.WithKeys("i"),
+		key.WithHelp("i", "toggle json fields"),
+	),
+	MinLogLevel: key.NewBinding(
+		key.WithKeys("M"),
+		key.WithHelp("M", "min level"),
+	),
+	NextError: key.NewBinding(
+		key.WithKeys("]"),
+		key.WithHelp("]", "next error"),
+	),
+	PrevError: key.NewBinding(
+		key.WithKeys("["),
+		key.WithHelp("[", "prev error"),
+	),
 	Spec: key.NewBinding(
 		key.WithKeys("p"),
 		key.WithHelp("p", "spec"),
@@ -108,4 +235,132 @@ var KeyMap = keyMap{
 		key.WithKeys("ctrl+w"),
 		key.WithHelp("ctrl+w", "toggle wrap"),
 	),
+	Confirm: key.NewBinding(
+		key.WithKeys("y"),
+		key.WithHelp("y", "confirm"),
+	),
+	Deny: key.NewBinding(
+		key.WithKeys("n"),
+		key.WithHelp("n", "cancel"),
+	),
+	JobDeployments: key.NewBinding(
+		key.WithKeys("D"),
+		key.WithHelp("D", "deployments"),
+	),
+	AllDeployments: key.NewBinding(
+		key.WithKeys("ctrl+e"),
+		key.WithHelp("ctrl+e", "all deployments"),
+	),
+	Promote: key.NewBinding(
+		key.WithKeys("P"),
+		key.WithHelp("P", "promote canaries"),
+	),
+	Fail: key.NewBinding(
+		key.WithKeys("F"),
+		key.WithHelp("F", "fail"),
+	),
+	PauseResume: key.NewBinding(
+		key.WithKeys("Z"),
+		key.WithHelp("Z", "pause/resume"),
+	),
+	JobEvaluations: key.NewBinding(
+		key.WithKeys("E"),
+		key.WithHelp("E", "evaluations"),
+	),
+	JobVersions: key.NewBinding(
+		key.WithKeys("H"),
+		key.WithHelp("H", "versions"),
+	),
+	MarkVersion: key.NewBinding(
+		key.WithKeys("x"),
+		key.WithHelp("x", "mark for diff"),
+	),
+	Revert: key.NewBinding(
+		key.WithKeys("R"),
+		key.WithHelp("R", "revert"),
+	),
+	StopJob: key.NewBinding(
+		key.WithKeys("X"),
+		key.WithHelp("X", "stop"),
+	),
+	PurgeJob: key.NewBinding(
+		key.WithKeys("ctrl+x"),
+		key.WithHelp("ctrl+x", "stop & purge"),
+	),
+	StartJob: key.NewBinding(
+		key.WithKeys("S"),
+		key.WithHelp("S", "start"),
+	),
+	RestartJob: key.NewBinding(
+		key.WithKeys("R"),
+		key.WithHelp("R", "restart"),
+	),
+	Scale: key.NewBinding(
+		key.WithKeys("c"),
+		key.WithHelp("c", "scale group"),
+	),
+	RestartTask: key.NewBinding(
+		key.WithKeys("R"),
+		key.WithHelp("R", "restart task"),
+	),
+	RestartAlloc: key.NewBinding(
+		key.WithKeys("ctrl+r"),
+		key.WithHelp("ctrl+r", "restart alloc"),
+	),
+	StopAlloc: key.NewBinding(
+		key.WithKeys("X"),
+		key.WithHelp("X", "stop alloc"),
+	),
+	Signal: key.NewBinding(
+		key.WithKeys("K"),
+		key.WithHelp("K", "signal"),
+	),
+	LaunchJob: key.NewBinding(
+		key.WithKeys("T"),
+		key.WithHelp("T", "dispatch/force launch"),
+	),
+	ExpandJob: key.NewBinding(
+		key.WithKeys("t"),
+		key.WithHelp("t", "expand/collapse children"),
+	),
+	Namespaces: key.NewBinding(
+		key.WithKeys("ctrl+n"),
+		key.WithHelp("ctrl+n", "namespaces"),
+	),
+	Profiles: key.NewBinding(
+		key.WithKeys("ctrl+p"),
+		key.WithHelp("ctrl+p", "profiles"),
+	),
+	Regions: key.NewBinding(
+		key.WithKeys("ctrl+o"),
+		key.WithHelp("ctrl+o", "regions"),
+	),
+	Variables: key.NewBinding(
+		key.WithKeys("ctrl+v"),
+		key.WithHelp("ctrl+v", "variables"),
+	),
+	NewVariable: key.NewBinding(
+		key.WithKeys("c"),
+		key.WithHelp("c", "new variable"),
+	),
+	EditVariable: key.NewBinding(
+		key.WithKeys("e"),
+		key.WithHelp("e", "edit"),
+	),
+	DeleteVariable: key.NewBinding(
+		key.WithKeys("X"),
+		key.WithHelp("X", "delete"),
+	),
+	RevealValues: key.NewBinding(
+		key.WithKeys("m"),
+		key.WithHelp("m", "reveal values"),
+	),
+	Plugins: key.NewBinding(
+		key.WithKeys("P"),
+		key.WithHelp("P", "csi plugins"),
+	),
+	HostVolumes: key.NewBinding(
+		key.WithKeys("H"),
+		key.WithHelp("H", "host volumes"),
+	),
 }
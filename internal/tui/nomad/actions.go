@@ -0,0 +1,24 @@
+package nomad
+
+import (
+	"fmt"
+	"github.com/robinovitch61/wander/internal/tui/formatter"
+)
+
+// ActionCompleteMsg is the result of a request that changes cluster state, e.g. promoting a deployment.
+// The result is shown as a toast on Page, which is then reloaded if it is still the current page
+type ActionCompleteMsg struct {
+	Page    Page
+	Message string
+	Err     error
+}
+
+func actionComplete(p Page, message, evalID string, err error) ActionCompleteMsg {
+	if err != nil {
+		return ActionCompleteMsg{Page: p, Err: err}
+	}
+	if evalID != "" {
+		message = fmt.Sprintf("%s (evaluation %s)", message, formatter.ShortAllocID(evalID))
+	}
+	return ActionCompleteMsg{Page: p, Message: message}
+}
@@ -0,0 +1,56 @@
+package nomad
+
+import (
+	"fmt"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/hashicorp/nomad/api"
+	"github.com/robinovitch61/wander/internal/tui/components/page"
+	"github.com/robinovitch61/wander/internal/tui/formatter"
+)
+
+var signals = []string{"SIGHUP", "SIGINT", "SIGQUIT", "SIGKILL", "SIGUSR1", "SIGUSR2", "SIGTERM", "SIGCONT", "SIGSTOP", "SIGWINCH"}
+
+func FetchSignals() tea.Cmd {
+	return func() tea.Msg {
+		var rows []page.Row
+		for _, signal := range signals {
+			rows = append(rows, page.Row{Key: signal, Row: signal})
+		}
+		return PageLoadedMsg{Page: SignalPage, TableHeader: []string{"Signal"}, AllPageRows: rows}
+	}
+}
+
+// RestartTask restarts taskName in the allocation
+func RestartTask(client api.Client, alloc api.Allocation, taskName string, p Page) tea.Cmd {
+	return func() tea.Msg {
+		err := client.Allocations().Restart(&alloc, taskName, &api.QueryOptions{Namespace: alloc.Namespace})
+		return actionComplete(p, fmt.Sprintf("Restarted task %s in %s", taskName, alloc.Name), "", err)
+	}
+}
+
+// RestartAlloc restarts all the tasks in the allocation, including prestart and sidecar tasks that aren't running
+func RestartAlloc(client api.Client, alloc api.Allocation, p Page) tea.Cmd {
+	return func() tea.Msg {
+		err := client.Allocations().RestartAllTasks(&alloc, &api.QueryOptions{Namespace: alloc.Namespace})
+		return actionComplete(p, fmt.Sprintf("Restarted all tasks in allocation %s %s", alloc.Name, formatter.ShortAllocID(alloc.ID)), "", err)
+	}
+}
+
+// StopAlloc stops the allocation, which is rescheduled if its job is still running
+func StopAlloc(client api.Client, alloc api.Allocation, p Page) tea.Cmd {
+	return func() tea.Msg {
+		resp, err := client.Allocations().Stop(&alloc, &api.QueryOptions{Namespace: alloc.Namespace})
+		var evalID string
+		if resp != nil {
+			evalID = resp.EvalID
+		}
+		return actionComplete(p, fmt.Sprintf("Stopped allocation %s %s", alloc.Name, formatter.ShortAllocID(alloc.ID)), evalID, err)
+	}
+}
+
+func SignalTask(client api.Client, alloc api.Allocation, taskName, signal string, p Page) tea.Cmd {
+	return func() tea.Msg {
+		err := client.Allocations().Signal(&alloc, &api.QueryOptions{Namespace: alloc.Namespace}, taskName, signal)
+		return actionComplete(p, fmt.Sprintf("Sent %s to task %s in %s", signal, taskName, alloc.Name), "", err)
+	}
+}
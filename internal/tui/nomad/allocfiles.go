@@ -0,0 +1,163 @@
+package nomad
+
+import (
+	"bytes"
+	"fmt"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/hashicorp/nomad/api"
+	"github.com/robinovitch61/wander/internal/fileio"
+	"github.com/robinovitch61/wander/internal/tui/components/page"
+	"github.com/robinovitch61/wander/internal/tui/formatter"
+	"github.com/robinovitch61/wander/internal/tui/message"
+	"io"
+	"os"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// AllocFilesRoot is the allocation directory, containing the shared alloc dir and a dir per task
+const AllocFilesRoot = "/"
+
+// maxAllocFileViewSize is how much of a file is shown. Larger files can be downloaded in full
+const maxAllocFileViewSize = 10 * 1024 * 1024
+
+func FetchAllocFiles(client api.Client, alloc api.Allocation, dir string) tea.Cmd {
+	return func() tea.Msg {
+		// see FetchLogs
+		api.ClientConnTimeout = 1 * time.Microsecond
+
+		files, _, err := client.AllocFS().List(&alloc, dir, nil)
+		if err != nil {
+			return message.ErrMsg{Err: err}
+		}
+
+		// directories first
+		sort.Slice(files, func(x, y int) bool {
+			if files[x].IsDir == files[y].IsDir {
+				return files[x].Name < files[y].Name
+			}
+			return files[x].IsDir
+		})
+
+		tableHeader, allPageData := allocFilesAsTable(files, dir)
+		return PageLoadedMsg{Page: AllocFilesPage, TableHeader: tableHeader, AllPageRows: allPageData}
+	}
+}
+
+func allocFilesAsTable(files []*api.AllocFileInfo, dir string) ([]string, []page.Row) {
+	columns := []string{"Name", "Size", "Mode", "Modified"}
+
+	var fileRows [][]string
+	var keys []string
+	for _, file := range files {
+		name, size := file.Name, strconv.FormatInt(file.Size, 10)
+		if file.IsDir {
+			name, size = file.Name+"/", "-"
+		}
+		fileRows = append(fileRows, []string{
+			name,
+			size,
+			file.FileMode,
+			formatter.FormatTime(file.ModTime),
+		})
+		keys = append(keys, toAllocFilesKey(path.Join(dir, file.Name), file.IsDir))
+	}
+
+	table := formatter.GetRenderedTableAsString(columns, fileRows)
+
+	var rows []page.Row
+	for idx, row := range table.ContentRows {
+		rows = append(rows, page.Row{Key: keys[idx], Row: row})
+	}
+
+	return table.HeaderRows, rows
+}
+
+func toAllocFilesKey(filePath string, isDir bool) string {
+	return filePath + keySeparator + strconv.FormatBool(isDir)
+}
+
+// AllocFileFromKey gets the path of a file in the allocation and whether it's a directory
+func AllocFileFromKey(key string) (string, bool) {
+	split := strings.Split(key, keySeparator)
+	isDir, _ := strconv.ParseBool(split[len(split)-1])
+	return split[0], isDir
+}
+
+// FetchAllocFile shows the start of a text file in the allocation
+func FetchAllocFile(client api.Client, alloc api.Allocation, filePath string) tea.Cmd {
+	return func() tea.Msg {
+		// see FetchLogs
+		api.ClientConnTimeout = 1 * time.Microsecond
+
+		info, _, err := client.AllocFS().Stat(&alloc, filePath, nil)
+		if err != nil {
+			return message.ErrMsg{Err: err}
+		}
+
+		var content []byte
+		if info.Size > 0 {
+			limit := info.Size
+			if limit > maxAllocFileViewSize {
+				limit = maxAllocFileViewSize
+			}
+			reader, err := client.AllocFS().ReadAt(&alloc, filePath, 0, limit, nil)
+			if err != nil {
+				return message.ErrMsg{Err: err}
+			}
+			defer reader.Close()
+			if content, err = io.ReadAll(reader); err != nil {
+				return message.ErrMsg{Err: err}
+			}
+		}
+
+		header := filePath
+		if info.Size > int64(len(content)) {
+			header = fmt.Sprintf("%s (first %d of %d bytes, download to see it all)", filePath, len(content), info.Size)
+		}
+
+		var rows []page.Row
+		if bytes.IndexByte(content, 0) >= 0 {
+			rows = append(rows, page.Row{Row: "Binary file, download to view it locally"})
+		} else {
+			for _, line := range strings.Split(formatter.CleanLogs(string(content)), "\n") {
+				rows = append(rows, page.Row{Row: line})
+			}
+		}
+
+		return PageLoadedMsg{Page: AllocFilePage, TableHeader: []string{header}, AllPageRows: rows}
+	}
+}
+
+// DownloadAllocFile saves the full content of a file in the allocation to saveDialogValue, a local path as accepted
+// when saving page content
+func DownloadAllocFile(client api.Client, alloc api.Allocation, filePath, saveDialogValue string, p Page) tea.Cmd {
+	return func() tea.Msg {
+		// see FetchLogs
+		api.ClientConnTimeout = 1 * time.Microsecond
+
+		reader, err := client.AllocFS().Cat(&alloc, filePath, nil)
+		if err != nil {
+			return actionComplete(p, "", "", err)
+		}
+		defer reader.Close()
+
+		// streamed to the file, as the files downloaded are often too large to view
+		f, savedPath, err := fileio.CreateFile(saveDialogValue)
+		if err != nil {
+			return actionComplete(p, "", "", err)
+		}
+		_, err = io.Copy(f, reader)
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			os.Remove(savedPath)
+			return actionComplete(p, "", "", err)
+		}
+		return actionComplete(p, fmt.Sprintf("Downloaded %s to %s", path.Base(filePath), savedPath), "", nil)
+	}
+}
@@ -0,0 +1,155 @@
+package nomad
+
+import (
+	"fmt"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/hashicorp/nomad/api"
+	"github.com/robinovitch61/wander/internal/tui/components/page"
+	"github.com/robinovitch61/wander/internal/tui/formatter"
+	"github.com/robinovitch61/wander/internal/tui/message"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type DeploymentInfo struct {
+	ID, Namespace, JobID, Status string
+}
+
+func FetchJobDeployments(client api.Client, jobID, jobNamespace string) tea.Cmd {
+	return func() tea.Msg {
+		deployments, _, err := client.Jobs().Deployments(jobID, true, &api.QueryOptions{Namespace: jobNamespace})
+		if err != nil {
+			return message.ErrMsg{Err: err}
+		}
+		tableHeader, allPageData := deploymentsAsTable(deployments, false)
+		return PageLoadedMsg{Page: JobDeploymentsPage, TableHeader: tableHeader, AllPageRows: allPageData}
+	}
+}
+
+func FetchAllDeployments(client api.Client) tea.Cmd {
+	return func() tea.Msg {
+		deployments, _, err := client.Deployments().List(nil)
+		if err != nil {
+			return message.ErrMsg{Err: err}
+		}
+		tableHeader, allPageData := deploymentsAsTable(deployments, true)
+		return PageLoadedMsg{Page: AllDeploymentsPage, TableHeader: tableHeader, AllPageRows: allPageData}
+	}
+}
+
+func getCanaries(state *api.DeploymentState) string {
+	if state.DesiredCanaries == 0 {
+		return "-"
+	}
+	promoted := ""
+	if state.Promoted {
+		promoted = " (promoted)"
+	}
+	return fmt.Sprintf("%d/%d%s", len(state.PlacedCanaries), state.DesiredCanaries, promoted)
+}
+
+func getProgressDeadline(status string, state *api.DeploymentState) string {
+	if status != "running" || state.RequireProgressBy.IsZero() {
+		return "-"
+	}
+	return formatter.FormatTime(state.RequireProgressBy)
+}
+
+func deploymentsAsTable(deployments []*api.Deployment, showJob bool) ([]string, []page.Row) {
+	sort.Sort(api.DeploymentIndexSort(deployments))
+
+	columns := []string{"Deployment", "Version", "Status", "Task Group", "Desired", "Placed", "Healthy", "Unhealthy", "Canaries", "Progress Deadline"}
+	if showJob {
+		columns = append([]string{"Job", "Namespace"}, columns...)
+	}
+
+	var deploymentRows [][]string
+	var keys []string
+	for _, deployment := range deployments {
+		var taskGroups []string
+		for taskGroup := range deployment.TaskGroups {
+			taskGroups = append(taskGroups, taskGroup)
+		}
+		sort.Strings(taskGroups)
+
+		for _, taskGroup := range taskGroups {
+			state := deployment.TaskGroups[taskGroup]
+			if state == nil {
+				continue
+			}
+			row := []string{
+				formatter.ShortAllocID(deployment.ID),
+				strconv.FormatUint(deployment.JobVersion, 10),
+				deployment.Status,
+				taskGroup,
+				strconv.Itoa(state.DesiredTotal),
+				strconv.Itoa(state.PlacedAllocs),
+				strconv.Itoa(state.HealthyAllocs),
+				strconv.Itoa(state.UnhealthyAllocs),
+				getCanaries(state),
+				getProgressDeadline(deployment.Status, state),
+			}
+			if showJob {
+				row = append([]string{deployment.JobID, deployment.Namespace}, row...)
+			}
+			deploymentRows = append(deploymentRows, row)
+			keys = append(keys, toDeploymentKey(deployment))
+		}
+	}
+
+	table := formatter.GetRenderedTableAsString(columns, deploymentRows)
+
+	var rows []page.Row
+	for idx, row := range table.ContentRows {
+		rows = append(rows, page.Row{Key: keys[idx], Row: row})
+	}
+
+	return table.HeaderRows, rows
+}
+
+func toDeploymentKey(deployment *api.Deployment) string {
+	return strings.Join([]string{deployment.ID, deployment.Namespace, deployment.JobID, deployment.Status}, keySeparator)
+}
+
+func DeploymentInfoFromKey(key string) DeploymentInfo {
+	split := strings.Split(key, keySeparator)
+	return DeploymentInfo{ID: split[0], Namespace: split[1], JobID: split[2], Status: split[3]}
+}
+
+func PromoteDeployment(client api.Client, deployment DeploymentInfo, p Page) tea.Cmd {
+	return func() tea.Msg {
+		resp, _, err := client.Deployments().PromoteAll(deployment.ID, &api.WriteOptions{Namespace: deployment.Namespace})
+		var evalID string
+		if resp != nil {
+			evalID = resp.EvalID
+		}
+		return actionComplete(p, fmt.Sprintf("Promoted deployment %s", formatter.ShortAllocID(deployment.ID)), evalID, err)
+	}
+}
+
+func FailDeployment(client api.Client, deployment DeploymentInfo, p Page) tea.Cmd {
+	return func() tea.Msg {
+		resp, _, err := client.Deployments().Fail(deployment.ID, &api.WriteOptions{Namespace: deployment.Namespace})
+		var evalID string
+		if resp != nil {
+			evalID = resp.EvalID
+		}
+		return actionComplete(p, fmt.Sprintf("Failed deployment %s", formatter.ShortAllocID(deployment.ID)), evalID, err)
+	}
+}
+
+func PauseDeployment(client api.Client, deployment DeploymentInfo, pause bool, p Page) tea.Cmd {
+	return func() tea.Msg {
+		resp, _, err := client.Deployments().Pause(deployment.ID, pause, &api.WriteOptions{Namespace: deployment.Namespace})
+		var evalID string
+		if resp != nil {
+			evalID = resp.EvalID
+		}
+		action := "Resumed"
+		if pause {
+			action = "Paused"
+		}
+		return actionComplete(p, fmt.Sprintf("%s deployment %s", action, formatter.ShortAllocID(deployment.ID)), evalID, err)
+	}
+}
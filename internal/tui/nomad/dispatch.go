@@ -0,0 +1,176 @@
+package nomad
+
+import (
+	"fmt"
+	"github.com/anmitsu/go-shlex"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/hashicorp/nomad/api"
+	"os"
+	"sort"
+	"strings"
+)
+
+const payloadPrefix = "@"
+
+// JobLaunchInfoMsg describes how a job can be launched, i.e. dispatched if it's parameterized or forced if it's periodic
+type JobLaunchInfoMsg struct {
+	Page          Page
+	JobID         string
+	Namespace     string
+	Region        string
+	Periodic      bool
+	Parameterized *api.ParameterizedJobConfig
+	Err           error
+}
+
+// ChildJobLaunchedMsg is the result of dispatching or forcing a launch of a job, which creates ChildJobID
+type ChildJobLaunchedMsg struct {
+	ChildJobID string
+	Namespace  string
+	Region     string
+	Message    string
+}
+
+// FetchJobLaunchInfo gets the launch info of the job in jobRegion, or in the client's region if jobRegion is empty
+func FetchJobLaunchInfo(client api.Client, jobID, jobNamespace, jobRegion string, p Page) tea.Cmd {
+	return func() tea.Msg {
+		job, _, err := client.Jobs().Info(jobID, &api.QueryOptions{Namespace: jobNamespace, Region: jobRegion})
+		if err != nil {
+			return JobLaunchInfoMsg{Page: p, Err: err}
+		}
+		info := JobLaunchInfoMsg{Page: p, JobID: jobID, Namespace: jobNamespace, Region: jobRegion}
+		switch {
+		case job.IsParameterized():
+			info.Parameterized = job.ParameterizedJob
+		case job.IsPeriodic():
+			info.Periodic = true
+		default:
+			info.Err = fmt.Errorf("job %s is neither parameterized nor periodic", jobID)
+		}
+		return info
+	}
+}
+
+// DispatchPrompt describes the input DispatchJob expects, e.g. `key=value other="quoted value" @path/to/payload`
+func DispatchPrompt(info JobLaunchInfoMsg) string {
+	var details []string
+	if len(info.Parameterized.MetaRequired) > 0 {
+		details = append(details, fmt.Sprintf("required meta %s", strings.Join(info.Parameterized.MetaRequired, ", ")))
+	}
+	if len(info.Parameterized.MetaOptional) > 0 {
+		details = append(details, fmt.Sprintf("optional meta %s", strings.Join(info.Parameterized.MetaOptional, ", ")))
+	}
+	switch info.Parameterized.Payload {
+	case "required":
+		details = append(details, "required "+payloadPrefix+"payload-file")
+	case "forbidden":
+	default:
+		details = append(details, "optional "+payloadPrefix+"payload-file")
+	}
+	if len(details) == 0 {
+		return fmt.Sprintf("Dispatch job %s (press enter): ", info.JobID)
+	}
+	if len(info.Parameterized.MetaRequired)+len(info.Parameterized.MetaOptional) > 0 {
+		details = append(details, `key="quoted value" for spaces`)
+	}
+	return fmt.Sprintf("Dispatch job %s (%s): ", info.JobID, strings.Join(details, "; "))
+}
+
+func parseDispatchInput(config *api.ParameterizedJobConfig, input string) (map[string]string, []byte, error) {
+	meta := make(map[string]string)
+	var payloadPath string
+	// values are quoted as in a shell if they have spaces
+	tokens, err := shlex.Split(input, true)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid input: %w", err)
+	}
+	for _, token := range tokens {
+		if strings.HasPrefix(token, payloadPrefix) {
+			payloadPath = strings.TrimPrefix(token, payloadPrefix)
+			continue
+		}
+		k, v, found := strings.Cut(token, "=")
+		if !found || k == "" {
+			return nil, nil, fmt.Errorf("expected key=value, got %q", token)
+		}
+		meta[k] = v
+	}
+
+	allowed := make(map[string]bool)
+	for _, k := range append(config.MetaRequired, config.MetaOptional...) {
+		allowed[k] = true
+	}
+	var unexpected []string
+	for k := range meta {
+		if !allowed[k] {
+			unexpected = append(unexpected, k)
+		}
+	}
+	if len(unexpected) > 0 {
+		sort.Strings(unexpected)
+		return nil, nil, fmt.Errorf("unexpected meta %s", strings.Join(unexpected, ", "))
+	}
+	var missing []string
+	for _, k := range config.MetaRequired {
+		if _, exists := meta[k]; !exists {
+			missing = append(missing, k)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, nil, fmt.Errorf("missing required meta %s", strings.Join(missing, ", "))
+	}
+
+	if payloadPath == "" {
+		if config.Payload == "required" {
+			return nil, nil, fmt.Errorf("payload is required")
+		}
+		return meta, nil, nil
+	}
+	if config.Payload == "forbidden" {
+		return nil, nil, fmt.Errorf("payload is forbidden")
+	}
+	payload, err := os.ReadFile(payloadPath)
+	if err != nil {
+		return nil, nil, err
+	}
+	return meta, payload, nil
+}
+
+func DispatchJob(client api.Client, info JobLaunchInfoMsg, input string) tea.Cmd {
+	return func() tea.Msg {
+		meta, payload, err := parseDispatchInput(info.Parameterized, input)
+		if err != nil {
+			return actionComplete(info.Page, "", "", err)
+		}
+		resp, _, err := client.Jobs().Dispatch(info.JobID, meta, payload, "", &api.WriteOptions{Namespace: info.Namespace, Region: info.Region})
+		if err != nil {
+			return actionComplete(info.Page, "", "", err)
+		}
+		return ChildJobLaunchedMsg{
+			ChildJobID: resp.DispatchedJobID,
+			Namespace:  info.Namespace,
+			Region:     info.Region,
+			Message:    fmt.Sprintf("Dispatched job %s", resp.DispatchedJobID),
+		}
+	}
+}
+
+func ForcePeriodicLaunch(client api.Client, info JobLaunchInfoMsg) tea.Cmd {
+	return func() tea.Msg {
+		evalID, _, err := client.Jobs().PeriodicForce(info.JobID, &api.WriteOptions{Namespace: info.Namespace, Region: info.Region})
+		if err != nil {
+			return actionComplete(info.Page, "", "", err)
+		}
+		// the evaluation is for the newly launched child job
+		eval, _, err := client.Evaluations().Info(evalID, &api.QueryOptions{Namespace: info.Namespace, Region: info.Region})
+		if err != nil {
+			return actionComplete(info.Page, fmt.Sprintf("Launched job %s", info.JobID), evalID, nil)
+		}
+		return ChildJobLaunchedMsg{
+			ChildJobID: eval.JobID,
+			Namespace:  info.Namespace,
+			Region:     info.Region,
+			Message:    fmt.Sprintf("Launched job %s", eval.JobID),
+		}
+	}
+}
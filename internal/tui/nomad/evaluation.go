@@ -0,0 +1,167 @@
+package nomad
+
+import (
+	"fmt"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/hashicorp/nomad/api"
+	"github.com/robinovitch61/wander/internal/tui/components/page"
+	"github.com/robinovitch61/wander/internal/tui/formatter"
+	"github.com/robinovitch61/wander/internal/tui/message"
+	"sort"
+	"strings"
+)
+
+const evaluationIndent = "  "
+
+func FetchEvaluation(client api.Client, evalID, evalNamespace string) tea.Cmd {
+	return func() tea.Msg {
+		eval, _, err := client.Evaluations().Info(evalID, &api.QueryOptions{Namespace: evalNamespace})
+		if err != nil {
+			return message.ErrMsg{Err: err}
+		}
+
+		// node names make scores readable, but the evaluation is still worth showing without them
+		nodeNames := make(map[string]string)
+		if nodes, _, err := client.Nodes().List(nil); err == nil {
+			for _, node := range nodes {
+				nodeNames[node.ID] = node.Name
+			}
+		}
+
+		var rows []page.Row
+		for _, line := range getEvaluationLines(eval, nodeNames) {
+			rows = append(rows, page.Row{Key: "", Row: line})
+		}
+
+		return PageLoadedMsg{
+			Page:        JobEvaluationPage,
+			TableHeader: []string{},
+			AllPageRows: rows,
+		}
+	}
+}
+
+func getEvaluationLines(eval *api.Evaluation, nodeNames map[string]string) []string {
+	lines := []string{
+		fmt.Sprintf("ID: %s", eval.ID),
+		fmt.Sprintf("Status: %s", eval.Status),
+		fmt.Sprintf("Status Description: %s", eval.StatusDescription),
+		fmt.Sprintf("Triggered By: %s", eval.TriggeredBy),
+		fmt.Sprintf("Type: %s", eval.Type),
+		fmt.Sprintf("Priority: %d", eval.Priority),
+		fmt.Sprintf("Created: %s", formatter.FormatTimeNs(eval.CreateTime)),
+		fmt.Sprintf("Previous Eval: %s", getShortIDOrDash(eval.PreviousEval)),
+		fmt.Sprintf("Next Eval: %s", getShortIDOrDash(eval.NextEval)),
+		fmt.Sprintf("Blocked Eval: %s", getShortIDOrDash(eval.BlockedEval)),
+		fmt.Sprintf("Queued Allocations: %s", getQueuedAllocations(eval.QueuedAllocations)),
+	}
+	if eval.QuotaLimitReached != "" {
+		lines = append(lines, fmt.Sprintf("Quota Limit Reached: %s", eval.QuotaLimitReached))
+	}
+
+	if len(eval.FailedTGAllocs) == 0 {
+		return append(lines, "", "No placement failures")
+	}
+
+	var taskGroups []string
+	for taskGroup := range eval.FailedTGAllocs {
+		taskGroups = append(taskGroups, taskGroup)
+	}
+	sort.Strings(taskGroups)
+
+	for _, taskGroup := range taskGroups {
+		metric := eval.FailedTGAllocs[taskGroup]
+		if metric == nil {
+			continue
+		}
+		lines = append(lines, "")
+		lines = append(lines, fmt.Sprintf("Task Group %q (failed to place %d allocation%s):", taskGroup, metric.CoalescedFailures+1, pluralSuffix(metric.CoalescedFailures+1)))
+		lines = append(lines, getAllocationMetricLines(metric, nodeNames)...)
+	}
+	return lines
+}
+
+func getAllocationMetricLines(metric *api.AllocationMetric, nodeNames map[string]string) []string {
+	lines := []string{
+		fmt.Sprintf("%sNodes Evaluated: %d", evaluationIndent, metric.NodesEvaluated),
+		fmt.Sprintf("%sNodes In Pool: %d", evaluationIndent, metric.NodesInPool),
+		fmt.Sprintf("%sNodes Filtered: %d", evaluationIndent, metric.NodesFiltered),
+		fmt.Sprintf("%sNodes Exhausted: %d", evaluationIndent, metric.NodesExhausted),
+	}
+
+	lines = append(lines, getCountLines("Nodes Available Per Datacenter", metric.NodesAvailable)...)
+	lines = append(lines, getCountLines("Filtered By Class", metric.ClassFiltered)...)
+	lines = append(lines, getCountLines("Filtered By Constraint", metric.ConstraintFiltered)...)
+	lines = append(lines, getCountLines("Exhausted By Class", metric.ClassExhausted)...)
+	lines = append(lines, getCountLines("Exhausted Dimensions", metric.DimensionExhausted)...)
+
+	if len(metric.QuotaExhausted) > 0 {
+		lines = append(lines, fmt.Sprintf("%sQuota Exhausted:", evaluationIndent))
+		for _, quota := range metric.QuotaExhausted {
+			lines = append(lines, evaluationIndent+evaluationIndent+quota)
+		}
+	}
+
+	if len(metric.ScoreMetaData) > 0 {
+		scores := metric.ScoreMetaData
+		sort.Slice(scores, func(x, y int) bool {
+			return scores[x].NormScore > scores[y].NormScore
+		})
+		lines = append(lines, fmt.Sprintf("%sNode Scores:", evaluationIndent))
+		for _, score := range scores {
+			lines = append(lines, fmt.Sprintf(
+				"%s%s %.3f (%s)",
+				evaluationIndent+evaluationIndent,
+				getNodeDisplayName(score.NodeID, nodeNames),
+				score.NormScore,
+				formatScores(score.Scores),
+			))
+		}
+	}
+	return lines
+}
+
+func getCountLines(title string, counts map[string]int) []string {
+	if len(counts) == 0 {
+		return nil
+	}
+	var keys []string
+	for k := range counts {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	lines := []string{fmt.Sprintf("%s%s:", evaluationIndent, title)}
+	for _, k := range keys {
+		lines = append(lines, fmt.Sprintf("%s%s: %d", evaluationIndent+evaluationIndent, k, counts[k]))
+	}
+	return lines
+}
+
+func getNodeDisplayName(nodeID string, nodeNames map[string]string) string {
+	if name, exists := nodeNames[nodeID]; exists {
+		return fmt.Sprintf("%s (%s)", name, formatter.ShortAllocID(nodeID))
+	}
+	return formatter.ShortAllocID(nodeID)
+}
+
+func formatScores(scores map[string]float64) string {
+	var names []string
+	for name := range scores {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var formatted []string
+	for _, name := range names {
+		formatted = append(formatted, fmt.Sprintf("%s %.3f", name, scores[name]))
+	}
+	return strings.Join(formatted, ", ")
+}
+
+func pluralSuffix(n int) string {
+	if n == 1 {
+		return ""
+	}
+	return "s"
+}
@@ -0,0 +1,88 @@
+package nomad
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/hashicorp/nomad/api"
+	"github.com/robinovitch61/wander/internal/tui/components/page"
+	"github.com/robinovitch61/wander/internal/tui/formatter"
+	"github.com/robinovitch61/wander/internal/tui/message"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+func FetchJobEvaluations(client api.Client, jobID, jobNamespace string) tea.Cmd {
+	return func() tea.Msg {
+		evaluations, _, err := client.Jobs().Evaluations(jobID, &api.QueryOptions{Namespace: jobNamespace})
+		if err != nil {
+			return message.ErrMsg{Err: err}
+		}
+
+		sort.Slice(evaluations, func(x, y int) bool {
+			return evaluations[x].CreateIndex > evaluations[y].CreateIndex
+		})
+
+		tableHeader, allPageData := evaluationsAsTable(evaluations)
+		return PageLoadedMsg{Page: JobEvaluationsPage, TableHeader: tableHeader, AllPageRows: allPageData}
+	}
+}
+
+func getQueuedAllocations(queued map[string]int) string {
+	var taskGroups []string
+	for taskGroup, count := range queued {
+		if count > 0 {
+			taskGroups = append(taskGroups, taskGroup+":"+strconv.Itoa(count))
+		}
+	}
+	if len(taskGroups) == 0 {
+		return "-"
+	}
+	sort.Strings(taskGroups)
+	return strings.Join(taskGroups, ", ")
+}
+
+func getShortIDOrDash(id string) string {
+	if short := formatter.ShortAllocID(id); short != "" {
+		return short
+	}
+	return "-"
+}
+
+func evaluationsAsTable(evaluations []*api.Evaluation) ([]string, []page.Row) {
+	columns := []string{"Eval ID", "Status", "Triggered By", "Type", "Priority", "Placement Failures", "Blocked Eval", "Queued", "Created"}
+
+	var evaluationRows [][]string
+	var keys []string
+	for _, eval := range evaluations {
+		evaluationRows = append(evaluationRows, []string{
+			formatter.ShortAllocID(eval.ID),
+			eval.Status,
+			eval.TriggeredBy,
+			eval.Type,
+			strconv.Itoa(eval.Priority),
+			strconv.FormatBool(len(eval.FailedTGAllocs) > 0),
+			getShortIDOrDash(eval.BlockedEval),
+			getQueuedAllocations(eval.QueuedAllocations),
+			formatter.FormatTimeNs(eval.CreateTime),
+		})
+		keys = append(keys, toEvaluationKey(eval))
+	}
+
+	table := formatter.GetRenderedTableAsString(columns, evaluationRows)
+
+	var rows []page.Row
+	for idx, row := range table.ContentRows {
+		rows = append(rows, page.Row{Key: keys[idx], Row: row})
+	}
+
+	return table.HeaderRows, rows
+}
+
+func toEvaluationKey(eval *api.Evaluation) string {
+	return eval.ID + " " + eval.Namespace
+}
+
+func EvalIDAndNamespaceFromKey(key string) (string, string) {
+	split := strings.Split(key, " ")
+	return split[0], split[1]
+}
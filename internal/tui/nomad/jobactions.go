@@ -0,0 +1,77 @@
+package nomad
+
+import (
+	"fmt"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/hashicorp/nomad/api"
+)
+
+func StopJob(client api.Client, jobID, jobNamespace string, purge bool, p Page) tea.Cmd {
+	return func() tea.Msg {
+		evalID, _, err := client.Jobs().Deregister(jobID, purge, &api.WriteOptions{Namespace: jobNamespace})
+		action := "Stopped"
+		if purge {
+			action = "Stopped and purged"
+		}
+		return actionComplete(p, fmt.Sprintf("%s job %s", action, jobID), evalID, err)
+	}
+}
+
+// StartJob re-registers a stopped job, which is what `nomad job start` does
+func StartJob(client api.Client, jobID, jobNamespace string, p Page) tea.Cmd {
+	return func() tea.Msg {
+		job, _, err := client.Jobs().Info(jobID, &api.QueryOptions{Namespace: jobNamespace})
+		if err != nil {
+			return actionComplete(p, "", "", err)
+		}
+		if job.Stop == nil || !*job.Stop {
+			return actionComplete(p, "", "", fmt.Errorf("job %s is not stopped", jobID))
+		}
+
+		stop := false
+		job.Stop = &stop
+		resp, _, err := client.Jobs().Register(job, &api.WriteOptions{Namespace: jobNamespace})
+		var evalID string
+		if resp != nil {
+			evalID = resp.EvalID
+		}
+		return actionComplete(p, fmt.Sprintf("Started job %s", jobID), evalID, err)
+	}
+}
+
+// RestartJob restarts the running tasks of every allocation of the job in place
+func RestartJob(client api.Client, jobID, jobNamespace string, p Page) tea.Cmd {
+	return func() tea.Msg {
+		allocs, _, err := client.Jobs().Allocations(jobID, false, &api.QueryOptions{Namespace: jobNamespace})
+		if err != nil {
+			return actionComplete(p, "", "", err)
+		}
+
+		restarted := 0
+		for _, alloc := range allocs {
+			if alloc.DesiredStatus != "run" || alloc.ClientStatus != "running" {
+				continue
+			}
+			err = client.Allocations().Restart(&api.Allocation{ID: alloc.ID}, "", &api.QueryOptions{Namespace: jobNamespace})
+			if err != nil {
+				return actionComplete(p, "", "", fmt.Errorf("restarted %d allocation%s of job %s before failing: %w", restarted, pluralSuffix(restarted), jobID, err))
+			}
+			restarted++
+		}
+		if restarted == 0 {
+			return actionComplete(p, "", "", fmt.Errorf("job %s has no running allocations", jobID))
+		}
+		return actionComplete(p, fmt.Sprintf("Restarted %d allocation%s of job %s", restarted, pluralSuffix(restarted), jobID), "", nil)
+	}
+}
+
+func ScaleTaskGroup(client api.Client, jobID, jobNamespace, taskGroup string, count int, p Page) tea.Cmd {
+	return func() tea.Msg {
+		resp, _, err := client.Jobs().Scale(jobID, taskGroup, &count, "Scaled from wander", false, nil, &api.WriteOptions{Namespace: jobNamespace})
+		var evalID string
+		if resp != nil {
+			evalID = resp.EvalID
+		}
+		return actionComplete(p, fmt.Sprintf("Scaled task group %s of job %s to %d", taskGroup, jobID, count), evalID, err)
+	}
+}
@@ -0,0 +1,101 @@
+package nomad
+
+import (
+	"fmt"
+	"github.com/hashicorp/nomad/api"
+	"strings"
+)
+
+const (
+	diffTypeNone    = "None"
+	diffTypeAdded   = "Added"
+	diffTypeDeleted = "Deleted"
+	diffTypeEdited  = "Edited"
+
+	diffIndent = "  "
+)
+
+// diffMarkers prefix each line of a rendered diff, matching the keys of constants.JobVersionDiffStyles
+var diffMarkers = map[string]string{
+	diffTypeAdded:   "+ ",
+	diffTypeDeleted: "- ",
+	diffTypeEdited:  "~ ",
+}
+
+func diffLine(diffType string, depth int, content string) string {
+	marker, exists := diffMarkers[diffType]
+	if !exists {
+		marker = "  "
+	}
+	return marker + strings.Repeat(diffIndent, depth) + content
+}
+
+func annotationsSuffix(annotations []string) string {
+	if len(annotations) == 0 {
+		return ""
+	}
+	return fmt.Sprintf(" (%s)", strings.Join(annotations, ", "))
+}
+
+func getJobDiffLines(diff *api.JobDiff) []string {
+	if diff == nil || diff.Type == diffTypeNone {
+		return nil
+	}
+	lines := []string{diffLine(diff.Type, 0, fmt.Sprintf("Job: %q", diff.ID))}
+	lines = append(lines, getFieldDiffLines(diff.Fields, 1)...)
+	lines = append(lines, getObjectDiffLines(diff.Objects, 1)...)
+	for _, taskGroup := range diff.TaskGroups {
+		lines = append(lines, getTaskGroupDiffLines(taskGroup, 1)...)
+	}
+	return lines
+}
+
+func getTaskGroupDiffLines(diff *api.TaskGroupDiff, depth int) []string {
+	if diff.Type == diffTypeNone {
+		return nil
+	}
+	lines := []string{diffLine(diff.Type, depth, fmt.Sprintf("Task Group: %q", diff.Name))}
+	lines = append(lines, getFieldDiffLines(diff.Fields, depth+1)...)
+	lines = append(lines, getObjectDiffLines(diff.Objects, depth+1)...)
+	for _, task := range diff.Tasks {
+		if task.Type == diffTypeNone {
+			continue
+		}
+		lines = append(lines, diffLine(task.Type, depth+1, fmt.Sprintf("Task: %q%s", task.Name, annotationsSuffix(task.Annotations))))
+		lines = append(lines, getFieldDiffLines(task.Fields, depth+2)...)
+		lines = append(lines, getObjectDiffLines(task.Objects, depth+2)...)
+	}
+	return lines
+}
+
+func getObjectDiffLines(diffs []*api.ObjectDiff, depth int) []string {
+	var lines []string
+	for _, diff := range diffs {
+		if diff.Type == diffTypeNone {
+			continue
+		}
+		lines = append(lines, diffLine(diff.Type, depth, diff.Name+":"))
+		lines = append(lines, getFieldDiffLines(diff.Fields, depth+1)...)
+		lines = append(lines, getObjectDiffLines(diff.Objects, depth+1)...)
+	}
+	return lines
+}
+
+func getFieldDiffLines(diffs []*api.FieldDiff, depth int) []string {
+	var lines []string
+	for _, diff := range diffs {
+		var value string
+		switch diff.Type {
+		case diffTypeAdded:
+			value = fmt.Sprintf("%q", diff.New)
+		case diffTypeDeleted:
+			value = fmt.Sprintf("%q", diff.Old)
+		case diffTypeEdited:
+			value = fmt.Sprintf("%q => %q", diff.Old, diff.New)
+		default:
+			continue
+		}
+		lines = append(lines, diffLine(diff.Type, depth, fmt.Sprintf("%s: %s%s", diff.Name, value, annotationsSuffix(diff.Annotations))))
+	}
+	return lines
+}
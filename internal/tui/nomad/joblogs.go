@@ -0,0 +1,239 @@
+package nomad
+
+import (
+	"fmt"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/hashicorp/nomad/api"
+	"github.com/robinovitch61/wander/internal/tui/components/page"
+	"github.com/robinovitch61/wander/internal/tui/formatter"
+	"github.com/robinovitch61/wander/internal/tui/message"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// maxJobLogsOffset limits how far back the logs of each task start, as there may be many tasks
+const maxJobLogsOffset = 50000
+
+// jobLogsBatchSize is the most lines read from the stream at once, so the view keeps up with many busy tasks
+const jobLogsBatchSize = 500
+
+// JobLogAlloc is an allocation whose logs are included in the logs of a job
+type JobLogAlloc struct {
+	ID, Name, NodeID string
+	TaskNames        []string
+}
+
+// JobLogLine is a complete line logged by a task
+type JobLogLine struct {
+	AllocID, TaskName, Line string
+	LogType                 LogType
+	Err                     error
+}
+
+// JobLogsStream merges the logs of tasks line by line, e.g. of every task in the running allocations of a job, or of
+// both outputs of a single task
+type JobLogsStream struct {
+	Chan    <-chan JobLogLine
+	Allocs  []JobLogAlloc
+	LogType LogType
+	cancel  chan struct{}
+	once    *sync.Once
+}
+
+// Close stops streaming logs. It's safe to call more than once, or on a stream that never started
+func (s JobLogsStream) Close() {
+	if s.once != nil {
+		s.once.Do(func() { close(s.cancel) })
+	}
+}
+
+type JobLogsStreamMsg struct {
+	Chan  <-chan JobLogLine
+	Lines []JobLogLine
+	Done  bool
+}
+
+// FetchJobLogs streams the logs of the running allocations of a job, limited to taskGroup if it's not empty
+func FetchJobLogs(client api.Client, jobID, jobNamespace, taskGroup string, logType LogType, logOffset int, logTail bool) tea.Cmd {
+	return func() tea.Msg {
+		// see FetchLogs
+		api.ClientConnTimeout = 1 * time.Microsecond
+
+		stubs, _, err := client.Jobs().Allocations(jobID, false, &api.QueryOptions{Namespace: jobNamespace})
+		if err != nil {
+			return message.ErrMsg{Err: err}
+		}
+
+		var allocs []JobLogAlloc
+		for _, stub := range stubs {
+			if stub.ClientStatus != "running" || (taskGroup != "" && stub.TaskGroup != taskGroup) {
+				continue
+			}
+			alloc := JobLogAlloc{ID: stub.ID, Name: stub.Name, NodeID: stub.NodeID}
+			for taskName := range stub.TaskStates {
+				alloc.TaskNames = append(alloc.TaskNames, taskName)
+			}
+			sort.Strings(alloc.TaskNames)
+			allocs = append(allocs, alloc)
+		}
+		sort.Slice(allocs, func(x, y int) bool {
+			if allocs[x].Name == allocs[y].Name {
+				return allocs[x].ID < allocs[y].ID
+			}
+			return allocs[x].Name < allocs[y].Name
+		})
+
+		offset := logOffset
+		if offset > maxJobLogsOffset {
+			offset = maxJobLogsOffset
+		}
+		stream := streamJobLogs(client, allocs, logType, offset, logTail)
+
+		columns := []string{fmt.Sprintf("%s of %d Allocations", logType.String(), len(allocs))}
+		table := formatter.GetRenderedTableAsString(columns, nil)
+		return PageLoadedMsg{Page: JobLogsPage, TableHeader: table.HeaderRows, JobLogsStream: stream}
+	}
+}
+
+func streamJobLogs(client api.Client, allocs []JobLogAlloc, logType LogType, offset int, follow bool) JobLogsStream {
+	out := make(chan JobLogLine, jobLogsBatchSize)
+	stream := JobLogsStream{Chan: out, Allocs: allocs, LogType: logType, cancel: make(chan struct{}), once: &sync.Once{}}
+
+	var wg sync.WaitGroup
+	for _, alloc := range allocs {
+		for _, taskName := range alloc.TaskNames {
+			for _, output := range logType.Split() {
+				wg.Add(1)
+				go func(alloc JobLogAlloc, taskName string, output LogType) {
+					defer wg.Done()
+					streamTaskLogs(client, alloc, taskName, output, offset, follow, stream.cancel, out)
+				}(alloc, taskName, output)
+			}
+		}
+	}
+	go func() {
+		wg.Wait()
+		close(out)
+	}()
+	return stream
+}
+
+// streamTaskLogs sends the logs of a task to out a line at a time, until the logs end or the stream is cancelled
+func streamTaskLogs(client api.Client, alloc JobLogAlloc, taskName string, logType LogType, offset int, follow bool, cancel chan struct{}, out chan<- JobLogLine) {
+	send := func(line JobLogLine) bool {
+		select {
+		case out <- line:
+			return true
+		case <-cancel:
+			return false
+		}
+	}
+
+	frames, errs := client.AllocFS().Logs(
+		&api.Allocation{ID: alloc.ID, NodeID: alloc.NodeID},
+		follow,
+		taskName,
+		logType.ShortString(),
+		"end",
+		int64(offset),
+		cancel,
+		nil,
+	)
+
+	var partialLine string
+	for {
+		select {
+		case <-cancel:
+			return
+		case err := <-errs:
+			send(JobLogLine{AllocID: alloc.ID, TaskName: taskName, LogType: logType, Err: err})
+			return
+		case frame, ok := <-frames:
+			if !ok {
+				if partialLine != "" {
+					send(JobLogLine{AllocID: alloc.ID, TaskName: taskName, LogType: logType, Line: partialLine})
+				}
+				return
+			}
+			lines := strings.Split(partialLine+formatter.CleanLogs(string(frame.Data)), "\n")
+			partialLine = lines[len(lines)-1]
+			for _, line := range lines[:len(lines)-1] {
+				if !send(JobLogLine{AllocID: alloc.ID, TaskName: taskName, LogType: logType, Line: line}) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// ReadJobLogsStreamNextMessage waits for the next line in the stream, then reads any others that are ready with it
+func ReadJobLogsStreamNextMessage(s JobLogsStream) tea.Cmd {
+	return func() tea.Msg {
+		line, ok := <-s.Chan
+		if !ok {
+			return JobLogsStreamMsg{Chan: s.Chan, Done: true}
+		}
+		lines := []JobLogLine{line}
+		for len(lines) < jobLogsBatchSize {
+			select {
+			case line, ok = <-s.Chan:
+				if !ok {
+					return JobLogsStreamMsg{Chan: s.Chan, Lines: lines, Done: true}
+				}
+				lines = append(lines, line)
+			default:
+				return JobLogsStreamMsg{Chan: s.Chan, Lines: lines}
+			}
+		}
+		return JobLogsStreamMsg{Chan: s.Chan, Lines: lines}
+	}
+}
+
+// JobLogRow is a line of the logs of a job, prefixed by the short ID of its allocation and the task name, and by its
+// output if logType interleaves them
+func JobLogRow(line JobLogLine, logType LogType) page.Row {
+	text := line.Line
+	if logType == StdOutAndStdErr {
+		text = LogGutter(line.LogType) + text
+	}
+	return page.Row{Key: line.AllocID, Row: fmt.Sprintf("%s %s %s", formatter.ShortAllocID(line.AllocID), line.TaskName, text)}
+}
+
+// JobLogPrefix is the start of every row of the logs of an allocation, used to color them
+func JobLogPrefix(allocID string) string {
+	return formatter.ShortAllocID(allocID) + " "
+}
+
+// FetchJobLogAllocs lists the allocations in the logs of a job, and whether their logs are shown
+func FetchJobLogAllocs(allocs []JobLogAlloc, hiddenAllocs map[string]bool) tea.Cmd {
+	return func() tea.Msg {
+		columns := []string{"Alloc ID", "Alloc Name", "Tasks", "Node ID", "Logs"}
+
+		var allocRows [][]string
+		var keys []string
+		for _, alloc := range allocs {
+			shown := "shown"
+			if hiddenAllocs[alloc.ID] {
+				shown = "hidden"
+			}
+			allocRows = append(allocRows, []string{
+				formatter.ShortAllocID(alloc.ID),
+				alloc.Name,
+				strings.Join(alloc.TaskNames, ","),
+				formatter.ShortAllocID(alloc.NodeID),
+				shown,
+			})
+			keys = append(keys, alloc.ID)
+		}
+
+		table := formatter.GetRenderedTableAsString(columns, allocRows)
+
+		var rows []page.Row
+		for idx, row := range table.ContentRows {
+			rows = append(rows, page.Row{Key: keys[idx], Row: row})
+		}
+		return PageLoadedMsg{Page: JobLogAllocsPage, TableHeader: table.HeaderRows, AllPageRows: rows}
+	}
+}
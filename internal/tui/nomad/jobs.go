@@ -2,6 +2,7 @@ package nomad
 
 import (
 	"errors"
+	"fmt"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/hashicorp/nomad/api"
 	"github.com/robinovitch61/wander/internal/tui/components/page"
@@ -10,35 +11,319 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
 )
 
-func FetchJobs(client api.Client, columns []string) tea.Cmd {
+const childJobPrefix = "└ "
+
+// maxConcurrentRegions limits the regions listed at once when listing jobs in all regions of a large federation
+const maxConcurrentRegions = 8
+
+// periodicSpecs caches the periodic config of parent jobs by region, namespace and job ID, as it's not in the job
+// list. It's fetched again when the job is modified, and forgotten when the job is no longer listed
+var periodicSpecs = struct {
+	sync.Mutex
+	byJob map[string]periodicSpec
+}{byJob: make(map[string]periodicSpec)}
+
+type periodicSpec struct {
+	region      string
+	modifyIndex uint64
+	periodic    *api.PeriodicConfig
+}
+
+// FetchJobs lists the jobs in the client's region, or in every region if allRegions is true, in which case a Region
+// column is added if it's not already in columns
+func FetchJobs(client api.Client, columns []string, expandedJobs map[string]bool, allRegions bool) tea.Cmd {
+	expanded := make(map[string]bool)
+	for k, v := range expandedJobs {
+		expanded[k] = v
+	}
 	return func() tea.Msg {
-		jobListOpts := &api.JobListOptions{
-			Fields: &api.JobListFields{Meta: true},
-		}
-		jobResults, _, err := client.Jobs().ListOptions(jobListOpts, nil)
-		if err != nil {
-			if strings.Contains(err.Error(), "UUID must be 36 characters") {
-				return message.ErrMsg{Err: errors.New("token must be 36 characters")}
-			} else if strings.Contains(err.Error(), "ACL token not found") {
-				return message.ErrMsg{Err: errors.New("token not authorized to list jobs")}
+		regions := []string{""}
+		if allRegions {
+			var err error
+			regions, err = client.Regions().List()
+			if err != nil {
+				return message.ErrMsg{Err: err}
 			}
-			return message.ErrMsg{Err: err}
+			columns = withRegionColumn(columns)
+		}
+
+		// regions are listed at once, and their jobs kept if others fail so one unreachable region doesn't hide the rest
+		regionGroups := make([][][]jobRowEntry, len(regions))
+		errs := make([]error, len(regions))
+		limit := make(chan struct{}, maxConcurrentRegions)
+		var wg sync.WaitGroup
+		for i, region := range regions {
+			wg.Add(1)
+			go func(i int, region string) {
+				defer wg.Done()
+				limit <- struct{}{}
+				defer func() { <-limit }()
+				jobResults, err := listJobs(client, region)
+				if err != nil {
+					errs[i] = err
+					return
+				}
+				regionGroups[i] = groupChildJobs(client, region, jobResults, expanded, showsNextLaunch(columns))
+			}(i, region)
+		}
+		wg.Wait()
+
+		var groups [][]jobRowEntry
+		var failedRegions []string
+		for i, region := range regions {
+			if errs[i] == nil {
+				groups = append(groups, regionGroups[i]...)
+				continue
+			}
+			if !allRegions {
+				return message.ErrMsg{Err: errs[i]}
+			}
+			failedRegions = append(failedRegions, fmt.Sprintf("region %s: %s", region, errs[i]))
+		}
+		if len(failedRegions) == len(regions) {
+			return message.ErrMsg{Err: errors.New(strings.Join(failedRegions, ", "))}
 		}
 
-		sort.Slice(jobResults, func(x, y int) bool {
-			firstJob := jobResults[x]
-			secondJob := jobResults[y]
+		// jobs with the same name in different regions are next to each other for comparison
+		sort.SliceStable(groups, func(x, y int) bool {
+			firstJob := groups[x][0].job
+			secondJob := groups[y][0].job
 			if firstJob.Name == secondJob.Name {
+				if firstJob.Namespace == secondJob.Namespace {
+					return groups[x][0].region < groups[y][0].region
+				}
 				return firstJob.Namespace < secondJob.Namespace
 			}
-			return jobResults[x].Name < jobResults[y].Name
+			return firstJob.Name < secondJob.Name
 		})
 
-		tableHeader, allPageData := jobResponsesAsTable(jobResults, columns)
-		return PageLoadedMsg{Page: JobsPage, TableHeader: tableHeader, AllPageRows: allPageData}
+		var entries []jobRowEntry
+		for _, group := range groups {
+			entries = append(entries, group...)
+		}
+		tableHeader, allPageData := jobResponsesAsTable(entries, columns)
+		loaded := PageLoadedMsg{Page: JobsPage, TableHeader: tableHeader, AllPageRows: allPageData}
+		if len(failedRegions) > 0 {
+			loaded.PartialErr = errors.New(strings.Join(failedRegions, ", "))
+		}
+		return loaded
+	}
+}
+
+// withRegionColumn adds the Region column after the first column if it's not already there
+func withRegionColumn(columns []string) []string {
+	for _, col := range columns {
+		if col == "Region" {
+			return columns
+		}
+	}
+	if len(columns) == 0 {
+		return []string{"Region"}
+	}
+	return append([]string{columns[0], "Region"}, columns[1:]...)
+}
+
+// listJobs lists the jobs in region, or in the client's region if region is empty
+func listJobs(client api.Client, region string) ([]*api.JobListStub, error) {
+	jobListOpts := &api.JobListOptions{
+		Fields: &api.JobListFields{Meta: true},
+	}
+	jobResults, _, err := client.Jobs().ListOptions(jobListOpts, &api.QueryOptions{Region: region})
+	if err != nil {
+		if strings.Contains(err.Error(), "UUID must be 36 characters") {
+			return nil, errors.New("token must be 36 characters")
+		} else if strings.Contains(err.Error(), "ACL token not found") {
+			return nil, errors.New("token not authorized to list jobs")
+		}
+		return nil, err
+	}
+	return jobResults, nil
+}
+
+// jobRowEntry is a row in the jobs table. Parent jobs summarize their children, which are only shown if the parent is expanded
+type jobRowEntry struct {
+	job        *api.JobListStub
+	region     string
+	isParent   bool
+	isChild    bool
+	children   []*api.JobListStub
+	nextLaunch string
+}
+
+func isParentJob(job *api.JobListStub) bool {
+	return job.ParentID == "" && (job.Periodic || job.ParameterizedJob)
+}
+
+func showsNextLaunch(columns []string) bool {
+	for _, col := range columns {
+		if col == "Next Launch" {
+			return true
+		}
+	}
+	return false
+}
+
+// groupChildJobs returns the rows for jobs in groups, where each group is a job followed by its children if it's an
+// expanded parent job. The next launches of periodic parent jobs are only fetched if withNextLaunch is true
+func groupChildJobs(client api.Client, region string, jobs []*api.JobListStub, expandedJobs map[string]bool, withNextLaunch bool) [][]jobRowEntry {
+	childrenByParent := make(map[string][]*api.JobListStub)
+	parents := make(map[string]bool)
+	for _, job := range jobs {
+		if isParentJob(job) {
+			parents[jobKey(job.ID, job.Namespace)] = true
+		}
+	}
+	for _, job := range jobs {
+		if parentKey := jobKey(job.ParentID, job.Namespace); job.ParentID != "" && parents[parentKey] {
+			childrenByParent[parentKey] = append(childrenByParent[parentKey], job)
+		}
+	}
+
+	var groups [][]jobRowEntry
+	for _, job := range jobs {
+		if job.ParentID != "" && parents[jobKey(job.ParentID, job.Namespace)] {
+			// listed under its parent
+			continue
+		}
+		if !isParentJob(job) {
+			groups = append(groups, []jobRowEntry{{job: job, region: region}})
+			continue
+		}
+
+		key := jobKey(job.ID, job.Namespace)
+		children := childrenByParent[key]
+		sort.Slice(children, func(x, y int) bool {
+			return children[x].SubmitTime > children[y].SubmitTime
+		})
+		nextLaunch := "-"
+		if withNextLaunch {
+			nextLaunch = getNextLaunch(client, region, job)
+		}
+		group := []jobRowEntry{{
+			job:        job,
+			region:     region,
+			isParent:   true,
+			children:   children,
+			nextLaunch: nextLaunch,
+		}}
+		if expandedJobs[expandKey(key, region)] {
+			for _, child := range children {
+				group = append(group, jobRowEntry{job: child, region: region, isChild: true})
+			}
+		}
+		groups = append(groups, group)
+	}
+	if withNextLaunch {
+		forgetPeriodicSpecs(region, jobs)
+	}
+	return groups
+}
+
+func getNextLaunch(client api.Client, region string, job *api.JobListStub) string {
+	if !job.Periodic || job.Stop {
+		return "-"
+	}
+	periodic, err := getPeriodicSpec(client, region, job)
+	if err != nil || periodic == nil || periodic.Enabled != nil && !*periodic.Enabled {
+		return "-"
+	}
+	// Nomad launches periodic jobs in their time zone, UTC by default
+	loc, err := periodic.GetLocation()
+	if err != nil {
+		return "-"
+	}
+	next, err := periodic.Next(time.Now().In(loc))
+	if err != nil {
+		return "-"
+	}
+	return formatter.FormatTime(next)
+}
+
+// getPeriodicSpec gets the periodic config of a job, which the job list doesn't include
+func getPeriodicSpec(client api.Client, region string, job *api.JobListStub) (*api.PeriodicConfig, error) {
+	key := periodicSpecKey(region, job)
+	periodicSpecs.Lock()
+	cached, exists := periodicSpecs.byJob[key]
+	periodicSpecs.Unlock()
+	if exists && cached.modifyIndex == job.ModifyIndex {
+		return cached.periodic, nil
+	}
+
+	fullJob, _, err := client.Jobs().Info(job.ID, &api.QueryOptions{Namespace: job.Namespace, Region: region})
+	if err != nil {
+		return nil, err
 	}
+	periodicSpecs.Lock()
+	periodicSpecs.byJob[key] = periodicSpec{region: region, modifyIndex: job.ModifyIndex, periodic: fullJob.Periodic}
+	periodicSpecs.Unlock()
+	return fullJob.Periodic, nil
+}
+
+// forgetPeriodicSpecs drops the cached periodic configs of jobs in region that aren't in jobs, e.g. as they were purged
+func forgetPeriodicSpecs(region string, jobs []*api.JobListStub) {
+	listed := make(map[string]bool)
+	for _, job := range jobs {
+		listed[periodicSpecKey(region, job)] = true
+	}
+	periodicSpecs.Lock()
+	defer periodicSpecs.Unlock()
+	for key, spec := range periodicSpecs.byJob {
+		if spec.region == region && !listed[key] {
+			delete(periodicSpecs.byJob, key)
+		}
+	}
+}
+
+func periodicSpecKey(region string, job *api.JobListStub) string {
+	return strings.Join([]string{region, job.Namespace, job.ID}, " ")
+}
+
+func getChildrenSummary(children []*api.JobListStub) string {
+	if len(children) == 0 {
+		return "none"
+	}
+	var pending, running, failed, complete int
+	for _, child := range children {
+		switch child.Status {
+		case "pending":
+			pending++
+		case "running":
+			running++
+		default:
+			if childFailed(child) {
+				failed++
+			} else {
+				complete++
+			}
+		}
+	}
+
+	var counts []string
+	for _, c := range []struct {
+		count int
+		name  string
+	}{{running, "running"}, {pending, "pending"}, {failed, "failed"}, {complete, "complete"}} {
+		if c.count > 0 {
+			counts = append(counts, fmt.Sprintf("%d %s", c.count, c.name))
+		}
+	}
+	return strings.Join(counts, ", ")
+}
+
+func childFailed(child *api.JobListStub) bool {
+	if child.JobSummary == nil {
+		return false
+	}
+	var failed, complete int
+	for _, summary := range child.JobSummary.Summary {
+		failed += summary.Failed + summary.Lost
+		complete += summary.Complete
+	}
+	return failed > 0 && complete == 0
 }
 
 func getCount(row *api.JobListStub) string {
@@ -50,9 +335,22 @@ func getCount(row *api.JobListStub) string {
 	return strconv.Itoa(num) + "/" + strconv.Itoa(denom)
 }
 
-func getJobRowFromColumns(row *api.JobListStub, columns []string) []string {
+func getJobRowFromColumns(entry jobRowEntry, columns []string) []string {
+	row := entry.job
+	jobName := row.ID
+	children, nextLaunch, region := "-", "-", "-"
+	if entry.isChild {
+		jobName = childJobPrefix + row.ID
+	}
+	if entry.isParent {
+		children, nextLaunch = getChildrenSummary(entry.children), entry.nextLaunch
+	}
+	if entry.region != "" {
+		region = entry.region
+	}
+
 	knownColMap := map[string]string{
-		"Job":          row.ID,
+		"Job":          jobName,
 		"Type":         row.Type,
 		"Namespace":    row.Namespace,
 		"Priority":     strconv.Itoa(row.Priority),
@@ -60,6 +358,9 @@ func getJobRowFromColumns(row *api.JobListStub, columns []string) []string {
 		"Count":        getCount(row),
 		"Submitted":    formatter.FormatTimeNs(row.SubmitTime),
 		"Since Submit": getUptime(row.Status, row.SubmitTime),
+		"Children":     children,
+		"Next Launch":  nextLaunch,
+		"Region":       region,
 	}
 
 	var rowEntries []string
@@ -77,12 +378,12 @@ func getJobRowFromColumns(row *api.JobListStub, columns []string) []string {
 	return rowEntries
 }
 
-func jobResponsesAsTable(jobResponse []*api.JobListStub, columns []string) ([]string, []page.Row) {
+func jobResponsesAsTable(entries []jobRowEntry, columns []string) ([]string, []page.Row) {
 	var jobResponseRows [][]string
 	var keys []string
-	for _, row := range jobResponse {
-		jobResponseRows = append(jobResponseRows, getJobRowFromColumns(row, columns))
-		keys = append(keys, toJobsKey(row))
+	for _, entry := range entries {
+		jobResponseRows = append(jobResponseRows, getJobRowFromColumns(entry, columns))
+		keys = append(keys, toJobsKey(entry.job, entry.region))
 	}
 	table := formatter.GetRenderedTableAsString(columns, jobResponseRows)
 
@@ -94,8 +395,18 @@ func jobResponsesAsTable(jobResponse []*api.JobListStub, columns []string) ([]st
 	return table.HeaderRows, rows
 }
 
-func toJobsKey(jobResponseEntry *api.JobListStub) string {
-	return jobResponseEntry.ID + " " + jobResponseEntry.Namespace
+// toJobsKey includes the parent job ID so child jobs can be collapsed from their own row, and the region if listing jobs
+// in all regions
+func toJobsKey(jobResponseEntry *api.JobListStub, region string) string {
+	return jobResponseEntry.ID + " " + jobResponseEntry.Namespace + " " + jobResponseEntry.ParentID + " " + region
+}
+
+func jobKey(jobID, jobNamespace string) string {
+	return jobID + " " + jobNamespace
+}
+
+func expandKey(jobKey, region string) string {
+	return jobKey + " " + region
 }
 
 func JobIDAndNamespaceFromKey(key string) (string, string) {
@@ -103,3 +414,23 @@ func JobIDAndNamespaceFromKey(key string) (string, string) {
 	return split[0], split[1]
 
 }
+
+// ExpandableJobKeyFromKey is the key of the parent job that expands or collapses to show the job, or the job itself
+// if it has no parent
+func ExpandableJobKeyFromKey(key string) string {
+	split := strings.Split(key, " ")
+	region := JobRegionFromKey(key)
+	if len(split) > 2 && split[2] != "" {
+		return expandKey(jobKey(split[2], split[1]), region)
+	}
+	return expandKey(jobKey(split[0], split[1]), region)
+}
+
+// JobRegionFromKey is the region of the job if jobs are listed in all regions, otherwise empty
+func JobRegionFromKey(key string) string {
+	split := strings.Split(key, " ")
+	if len(split) > 3 {
+		return split[3]
+	}
+	return ""
+}
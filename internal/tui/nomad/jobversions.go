@@ -0,0 +1,131 @@
+package nomad
+
+import (
+	"fmt"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/hashicorp/nomad/api"
+	"github.com/robinovitch61/wander/internal/tui/components/page"
+	"github.com/robinovitch61/wander/internal/tui/formatter"
+	"github.com/robinovitch61/wander/internal/tui/message"
+	"strconv"
+)
+
+func FetchJobVersions(client api.Client, jobID, jobNamespace string) tea.Cmd {
+	return func() tea.Msg {
+		versions, diffs, _, err := client.Jobs().Versions(jobID, true, &api.QueryOptions{Namespace: jobNamespace})
+		if err != nil {
+			return message.ErrMsg{Err: err}
+		}
+		tableHeader, allPageData := jobVersionsAsTable(versions, diffs)
+		return PageLoadedMsg{Page: JobVersionsPage, TableHeader: tableHeader, AllPageRows: allPageData}
+	}
+}
+
+// getChangeCount counts the top level job fields, objects and task groups that changed from the previous version
+func getChangeCount(diffs []*api.JobDiff, idx int) string {
+	if idx >= len(diffs) || diffs[idx] == nil {
+		return "-"
+	}
+	diff := diffs[idx]
+	count := 0
+	for _, field := range diff.Fields {
+		if field.Type != diffTypeNone {
+			count++
+		}
+	}
+	for _, object := range diff.Objects {
+		if object.Type != diffTypeNone {
+			count++
+		}
+	}
+	for _, taskGroup := range diff.TaskGroups {
+		if taskGroup.Type != diffTypeNone {
+			count++
+		}
+	}
+	return strconv.Itoa(count)
+}
+
+func jobVersionsAsTable(versions []*api.Job, diffs []*api.JobDiff) ([]string, []page.Row) {
+	columns := []string{"Version", "Stable", "Status", "Submitted", "Changes"}
+
+	var versionRows [][]string
+	var keys []string
+	for idx, version := range versions {
+		versionRows = append(versionRows, []string{
+			strconv.FormatUint(*version.Version, 10),
+			strconv.FormatBool(*version.Stable),
+			*version.Status,
+			formatter.FormatTimeNs(*version.SubmitTime),
+			getChangeCount(diffs, idx),
+		})
+		keys = append(keys, strconv.FormatUint(*version.Version, 10))
+	}
+
+	table := formatter.GetRenderedTableAsString(columns, versionRows)
+
+	var rows []page.Row
+	for idx, row := range table.ContentRows {
+		rows = append(rows, page.Row{Key: keys[idx], Row: row})
+	}
+
+	return table.HeaderRows, rows
+}
+
+func JobVersionFromKey(key string) (uint64, error) {
+	return strconv.ParseUint(key, 10, 64)
+}
+
+// FetchJobVersionDiff shows the changes from version fromVersion to version toVersion. Nomad only diffs each
+// version against the one before it, so every step in between is shown in order
+func FetchJobVersionDiff(client api.Client, jobID, jobNamespace string, fromVersion, toVersion uint64) tea.Cmd {
+	return func() tea.Msg {
+		versions, diffs, _, err := client.Jobs().Versions(jobID, true, &api.QueryOptions{Namespace: jobNamespace})
+		if err != nil {
+			return message.ErrMsg{Err: err}
+		}
+
+		var lines []string
+		// versions are ordered newest first, and diffs[i] is the diff from versions[i+1] to versions[i]
+		for idx := len(versions) - 2; idx >= 0; idx-- {
+			previous, current := *versions[idx+1].Version, *versions[idx].Version
+			if previous < fromVersion || current > toVersion || idx >= len(diffs) {
+				continue
+			}
+			if len(lines) > 0 {
+				lines = append(lines, "")
+			}
+			lines = append(lines, fmt.Sprintf("Version %d -> %d", previous, current))
+			diffLines := getJobDiffLines(diffs[idx])
+			if len(diffLines) == 0 {
+				diffLines = []string{"  No changes"}
+			}
+			lines = append(lines, diffLines...)
+		}
+		if len(lines) == 0 {
+			lines = []string{fmt.Sprintf("No previous version to compare version %d against", toVersion)}
+		}
+
+		var rows []page.Row
+		for _, line := range lines {
+			rows = append(rows, page.Row{Key: "", Row: line})
+		}
+
+		return PageLoadedMsg{
+			Page:        JobVersionDiffPage,
+			TableHeader: []string{},
+			AllPageRows: rows,
+		}
+	}
+}
+
+func RevertJob(client api.Client, jobID, jobNamespace string, version uint64, p Page) tea.Cmd {
+	return func() tea.Msg {
+		resp, _, err := client.Jobs().Revert(jobID, version, nil, &api.WriteOptions{Namespace: jobNamespace}, "", "")
+		var evalID string
+		if resp != nil {
+			evalID = resp.EvalID
+		}
+		return actionComplete(p, fmt.Sprintf("Reverted job %s to version %d", jobID, version), evalID, err)
+	}
+}
@@ -0,0 +1,225 @@
+package nomad
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/itchyny/gojq"
+	"github.com/robinovitch61/wander/internal/tui/components/page"
+	"github.com/robinovitch61/wander/internal/tui/constants"
+	"github.com/robinovitch61/wander/internal/tui/formatter"
+	"sort"
+	"strings"
+)
+
+// JSONLogs renders JSON log lines as a table, with a column for each field of the object the jq Query turns a line
+// into. Lines that aren't JSON, or that the query doesn't turn into an object, are shown raw in the last column.
+// Columns appear and widen as lines come in, so the whole table is rendered again when that happens
+type JSONLogs struct {
+	Query   *gojq.Code
+	LogType LogType
+	// MaxLines is the most lines kept, evicting the oldest ones. 0 for no limit
+	MaxLines int
+
+	keys        []string
+	widths      []int
+	gutterWidth int
+	lines       []jsonLogLine
+	partialLine string
+}
+
+type jsonLogLine struct {
+	raw, gutter string
+	fields      map[string]string
+}
+
+// Enabled is whether logs are rendered as a table
+func (j JSONLogs) Enabled() bool {
+	return j.Query != nil
+}
+
+// Append adds logs, which may end with an unfinished line that's completed by the next call, returning the rows of the
+// new lines, which start with gutter. If the columns changed to fit them, rerender is true and the earlier rows should
+// be rendered again too
+func (j *JSONLogs) Append(logs, gutter string) (rows []page.Row, rerender bool) {
+	lines := strings.Split(j.partialLine+logs, "\n")
+	j.partialLine = lines[len(lines)-1]
+	return j.AppendLines(lines[:len(lines)-1], gutter)
+}
+
+// AppendLines adds complete lines like Append, e.g. with a gutter to mark the output they're from
+func (j *JSONLogs) AppendLines(lines []string, gutter string) (rows []page.Row, rerender bool) {
+	var newLines []jsonLogLine
+	for _, raw := range lines {
+		if strings.TrimSpace(raw) == "" {
+			continue
+		}
+		line := jsonLogLine{raw: raw, gutter: gutter, fields: j.parse(raw)}
+		if j.fit(line) {
+			rerender = true
+		}
+		if w := lipgloss.Width(gutter); w > j.gutterWidth {
+			j.gutterWidth = w
+			rerender = true
+		}
+		j.lines = append(j.lines, line)
+		newLines = append(newLines, line)
+	}
+	if j.MaxLines > 0 && len(j.lines) > j.MaxLines {
+		j.lines = j.lines[len(j.lines)-j.MaxLines:]
+	}
+
+	for _, line := range newLines {
+		rows = append(rows, j.row(line))
+	}
+	return rows, rerender
+}
+
+// Render renders every line so far as a table
+func (j JSONLogs) Render() ([]string, []page.Row) {
+	var columns []string
+	for _, k := range j.keys {
+		columns = append(columns, jsonLogColumnName(k))
+	}
+	if len(columns) == 0 {
+		columns = []string{j.LogType.String()}
+	}
+	header := strings.Repeat(" ", j.gutterWidth) + j.join(columns)
+
+	var rows []page.Row
+	for _, line := range j.lines {
+		rows = append(rows, j.row(line))
+	}
+	return []string{header}, rows
+}
+
+// parse runs the query on a line, returning nil if the line can't be shown as fields
+func (j JSONLogs) parse(raw string) map[string]string {
+	trimmed := strings.TrimSpace(raw)
+	if !strings.HasPrefix(trimmed, "{") {
+		return nil
+	}
+	var parsed map[string]interface{}
+	if err := json.Unmarshal([]byte(trimmed), &parsed); err != nil {
+		return nil
+	}
+	v, ok := j.Query.Run(parsed).Next()
+	if !ok {
+		return nil
+	}
+	object, ok := v.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	fields := make(map[string]string)
+	for k, value := range object {
+		fields[k] = formatJSONLogValue(value)
+	}
+	return fields
+}
+
+func formatJSONLogValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "-"
+	case string:
+		return strings.ReplaceAll(formatter.CleanLogs(v), "\n", `\n`)
+	}
+	j, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(j)
+}
+
+// fit adds any new columns for the line and widens the columns to fit it, returning true if the columns changed
+func (j *JSONLogs) fit(line jsonLogLine) bool {
+	changed := false
+	for k := range line.fields {
+		if !j.hasKey(k) {
+			j.keys = append(j.keys, k)
+			changed = true
+		}
+	}
+	if changed {
+		// keys are ordered like the columns of the events jq queries, e.g. "1:Time", "2:Level"
+		sort.Strings(j.keys)
+		j.widths = make([]int, len(j.keys))
+		for i, k := range j.keys {
+			j.widths[i] = lipgloss.Width(jsonLogColumnName(k))
+		}
+		for _, l := range j.lines {
+			j.widen(l)
+		}
+	}
+	return j.widen(line) || changed
+}
+
+func (j *JSONLogs) widen(line jsonLogLine) bool {
+	if line.fields == nil || len(j.keys) == 0 {
+		// raw lines are in the last column, which doesn't need padding
+		return false
+	}
+	changed := false
+	for i, k := range j.keys[:len(j.keys)-1] {
+		if w := lipgloss.Width(line.fields[k]); w > j.widths[i] {
+			j.widths[i] = w
+			changed = true
+		}
+	}
+	return changed
+}
+
+func (j JSONLogs) hasKey(key string) bool {
+	for _, k := range j.keys {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
+
+// row keeps the raw line as the key, so selecting it shows the full JSON
+func (j JSONLogs) row(line jsonLogLine) page.Row {
+	gutter := line.gutter + strings.Repeat(" ", j.gutterWidth-lipgloss.Width(line.gutter))
+	if len(j.keys) == 0 {
+		return page.Row{Key: line.raw, Row: gutter + line.raw}
+	}
+	cells := make([]string, len(j.keys))
+	if line.fields == nil {
+		cells[len(cells)-1] = line.raw
+	} else {
+		for i, k := range j.keys {
+			if v, exists := line.fields[k]; exists {
+				cells[i] = v
+			} else {
+				cells[i] = "-"
+			}
+		}
+	}
+	return page.Row{Key: line.raw, Row: gutter + j.join(cells)}
+}
+
+// join pads cells to the column widths and separates them like formatter.GetRenderedTableAsString does
+func (j JSONLogs) join(cells []string) string {
+	var b strings.Builder
+	for i, cell := range cells {
+		b.WriteString(cell)
+		if i < len(cells)-1 {
+			if w := j.widths[i] - lipgloss.Width(cell); w > 0 {
+				b.WriteString(strings.Repeat(" ", w))
+			}
+			b.WriteString(constants.TableSeparator)
+		}
+	}
+	return b.String()
+}
+
+// jsonLogColumnName removes any ordering prefix, e.g. "1:Time" is shown as "Time"
+func jsonLogColumnName(key string) string {
+	if prefix, name, found := strings.Cut(key, ":"); found && strings.Trim(prefix, "0123456789") == "" {
+		return name
+	}
+	return key
+}
@@ -0,0 +1,138 @@
+package nomad
+
+import (
+	"fmt"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/robinovitch61/wander/internal/tui/components/page"
+	"github.com/robinovitch61/wander/internal/tui/style"
+	"regexp"
+	"strings"
+)
+
+type LogLevel int8
+
+const (
+	AnyLevel LogLevel = iota
+	DebugLevel
+	InfoLevel
+	WarnLevel
+	ErrorLevel
+)
+
+func (l LogLevel) String() string {
+	switch l {
+	case AnyLevel:
+		return "all"
+	case DebugLevel:
+		return "debug"
+	case InfoLevel:
+		return "info"
+	case WarnLevel:
+		return "warn"
+	case ErrorLevel:
+		return "error"
+	}
+	return "unknown"
+}
+
+// LogLevelFromString gets the level named as in the config file, e.g. "warn"
+func LogLevelFromString(s string) (LogLevel, error) {
+	for l := DebugLevel; l <= ErrorLevel; l++ {
+		if strings.ToLower(s) == l.String() {
+			return l, nil
+		}
+	}
+	return AnyLevel, fmt.Errorf("unknown log level %s, must be one of debug, info, warn or error", s)
+}
+
+// Next cycles through the minimum levels that logs can be filtered to
+func (l LogLevel) Next() LogLevel {
+	return (l + 1) % (ErrorLevel + 1)
+}
+
+// Style highlights lines of the level, apart from info lines, which are left as is
+func (l LogLevel) Style() (lipgloss.Style, bool) {
+	switch l {
+	case DebugLevel:
+		return style.LogLevelDebug, true
+	case WarnLevel:
+		return style.LogLevelWarn, true
+	case ErrorLevel:
+		return style.LogLevelError, true
+	}
+	return lipgloss.Style{}, false
+}
+
+// LogLevelPatterns detect the level of log lines, with a pattern per level
+type LogLevelPatterns map[LogLevel]*regexp.Regexp
+
+// DefaultLogLevelPatterns match the level markers of common log formats: uppercase or bracketed levels, logfmt's
+// level=error and JSON's "level": "error"
+var DefaultLogLevelPatterns = LogLevelPatterns{
+	DebugLevel: regexp.MustCompile(`(?i:level"?\s*[=:]\s*"?(debug|trace)\b)|\b(DEBUG|TRACE)\b|(?i:\[(debug|trace)])`),
+	InfoLevel:  regexp.MustCompile(`(?i:level"?\s*[=:]\s*"?(info|notice)\b)|\b(INFO|NOTICE)\b|(?i:\[(info|notice)])`),
+	WarnLevel:  regexp.MustCompile(`(?i:level"?\s*[=:]\s*"?(warn|warning)\b)|\b(WARN|WARNING)\b|(?i:\[(warn|warning)])`),
+	ErrorLevel: regexp.MustCompile(`(?i:level"?\s*[=:]\s*"?(error|err|fatal|panic|crit|critical)\b)|\b(ERROR|ERR|FATAL|PANIC|CRIT|CRITICAL)\b|(?i:\[(error|err|fatal|panic|crit|critical)])`),
+}
+
+// WithDefaults fills in the default pattern for any level without one
+func (p LogLevelPatterns) WithDefaults() LogLevelPatterns {
+	patterns := make(LogLevelPatterns)
+	for l, pattern := range DefaultLogLevelPatterns {
+		patterns[l] = pattern
+	}
+	for l, pattern := range p {
+		patterns[l] = pattern
+	}
+	return patterns
+}
+
+// LogLevels detects the levels of log rows, remembering them as rows are styled and filtered on every change to the
+// logs being viewed
+type LogLevels struct {
+	patterns LogLevelPatterns
+	levels   map[string]LogLevel
+	// maxCached is the most lines remembered before forgetting them all, e.g. once lines are evicted. 0 for no limit
+	maxCached int
+}
+
+// NewLogLevels remembers the levels of about as many lines as are kept, maxLines, or of every line if it's 0
+func NewLogLevels(patterns LogLevelPatterns, maxLines int) LogLevels {
+	return LogLevels{patterns: patterns, levels: make(map[string]LogLevel), maxCached: logLinesCached(maxLines)}
+}
+
+// logLinesCached leaves room for a few lines per row, e.g. its key and the row itself, so remembering the lines of
+// the rows kept doesn't forget them all on every change
+func logLinesCached(maxLines int) int {
+	return 2 * maxLines
+}
+
+// Of gets the level of a log row from whichever pattern matches earliest in the line. The rows of JSON logs have the
+// raw line as their key, which is used instead of the fields shown
+func (l LogLevels) Of(row page.Row) LogLevel {
+	line := row.Row
+	if row.Key != "" {
+		line = row.Key
+	}
+	if level, exists := l.levels[line]; exists {
+		return level
+	}
+
+	level, earliest := AnyLevel, -1
+	for lvl := DebugLevel; lvl <= ErrorLevel; lvl++ {
+		pattern, exists := l.patterns[lvl]
+		if !exists {
+			continue
+		}
+		if loc := pattern.FindStringIndex(line); loc != nil && (earliest < 0 || loc[0] < earliest) {
+			level, earliest = lvl, loc[0]
+		}
+	}
+	if l.levels != nil {
+		if l.maxCached > 0 && len(l.levels) >= l.maxCached {
+			clear(l.levels)
+		}
+		l.levels[line] = level
+	}
+	return level
+}
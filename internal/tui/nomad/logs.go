@@ -1,10 +1,16 @@
 package nomad
 
 import (
+	"fmt"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/hashicorp/nomad/api"
+	"github.com/itchyny/gojq"
+	"github.com/robinovitch61/wander/internal/fileio"
 	"github.com/robinovitch61/wander/internal/tui/components/page"
 	"github.com/robinovitch61/wander/internal/tui/formatter"
+	"io"
+	"os"
+	"path"
 	"strings"
 	"time"
 )
@@ -14,6 +20,8 @@ type LogType int8
 const (
 	StdOut LogType = iota
 	StdErr
+	// StdOutAndStdErr interleaves both outputs in the order lines arrive
+	StdOutAndStdErr
 )
 
 type LogsStreamMsg struct {
@@ -27,6 +35,8 @@ func (p LogType) String() string {
 		return "Stdout Logs"
 	case StdErr:
 		return "Stderr Logs"
+	case StdOutAndStdErr:
+		return "Stdout and Stderr Logs"
 	}
 	return "unknown"
 }
@@ -37,11 +47,35 @@ func (p LogType) ShortString() string {
 		return "stdout"
 	case StdErr:
 		return "stderr"
+	case StdOutAndStdErr:
+		return "stdout and stderr"
 	}
 	return "unknown"
 }
 
-func FetchLogs(client api.Client, alloc api.Allocation, taskName string, logType LogType, logOffset int, logTail bool) tea.Cmd {
+// Split gets the outputs the logs are read from
+func (p LogType) Split() []LogType {
+	if p == StdOutAndStdErr {
+		return []LogType{StdOut, StdErr}
+	}
+	return []LogType{p}
+}
+
+// LogGutter marks which output a line is from when outputs are interleaved
+func LogGutter(logType LogType) string {
+	if logType == StdErr {
+		return "err│ "
+	}
+	return "out│ "
+}
+
+// TaskLogRow is a line of interleaved logs of a task, keeping the line without its gutter as the key
+func TaskLogRow(line JobLogLine) page.Row {
+	return page.Row{Key: line.Line, Row: LogGutter(line.LogType) + line.Line}
+}
+
+// FetchLogs gets the logs of a task, rendered as a table by jsonQuery if it's not nil
+func FetchLogs(client api.Client, alloc api.Allocation, taskName string, logType LogType, logOffset int, logTail bool, jsonQuery *gojq.Code) tea.Cmd {
 	return func() tea.Msg {
 		// This is currently very important and strange. The logs api attempts to go through the node directly
 		// by default. The default timeout for this is 1 second. If it fails, it falls silently to going through
@@ -49,6 +83,17 @@ func FetchLogs(client api.Client, alloc api.Allocation, taskName string, logType
 		// the timeout to something tiny.
 		api.ClientConnTimeout = 1 * time.Microsecond
 
+		jsonLogs := JSONLogs{Query: jsonQuery, LogType: logType}
+		if logType == StdOutAndStdErr {
+			task := JobLogAlloc{ID: alloc.ID, Name: alloc.Name, NodeID: alloc.NodeID, TaskNames: []string{taskName}}
+			stream := streamJobLogs(client, []JobLogAlloc{task}, logType, logOffset, logTail)
+			tableHeader, allPageData := logsAsTable(nil, logType)
+			if jsonLogs.Enabled() {
+				tableHeader, allPageData = jsonLogs.Render()
+			}
+			return PageLoadedMsg{Page: LogsPage, TableHeader: tableHeader, AllPageRows: allPageData, JobLogsStream: stream, JSONLogs: jsonLogs}
+		}
+
 		closeLogConn := make(chan struct{})   // never closed for now
 		logsChan, _ := client.AllocFS().Logs( // TODO LEO: deal with error channel
 			&alloc,
@@ -70,13 +115,81 @@ func FetchLogs(client api.Client, alloc api.Allocation, taskName string, logType
 			}
 
 			tabReplacedLogs := formatter.CleanLogs(allLogs)
+			if jsonLogs.Enabled() {
+				jsonLogs.Append(tabReplacedLogs+"\n", "")
+			}
 			logRows = strings.Split(tabReplacedLogs, "\n")
 		} else {
 			logsStream = LogsStream{logsChan, logType}
 		}
 		tableHeader, allPageData := logsAsTable(logRows, logType)
-		return PageLoadedMsg{Page: LogsPage, TableHeader: tableHeader, AllPageRows: allPageData, LogsStream: logsStream}
+		if jsonLogs.Enabled() {
+			tableHeader, allPageData = jsonLogs.Render()
+		}
+		return PageLoadedMsg{Page: LogsPage, TableHeader: tableHeader, AllPageRows: allPageData, LogsStream: logsStream, JSONLogs: jsonLogs}
+	}
+}
+
+// LogFileName is the default name of the file that the logs of a task are saved to
+func LogFileName(taskName string, logType LogType) string {
+	if logType == StdOutAndStdErr {
+		return taskName + ".log"
+	}
+	return fmt.Sprintf("%s.%s.log", taskName, logType.ShortString())
+}
+
+// LogsDownloadedMsg is the result of downloading the logs of a task. Unlike other actions, the logs aren't reloaded
+// after, as that would interrupt following them
+type LogsDownloadedMsg struct {
+	Message string
+	Err     error
+}
+
+// DownloadLogs saves every log file of a task, including rotated ones, to saveDialogValue, a local path as accepted
+// when saving page content. The files are copied as they are, rather than what's loaded in wander, and interleaved logs
+// are saved one output after the other. Paths ending in .gz are compressed
+func DownloadLogs(client api.Client, alloc api.Allocation, taskName string, logType LogType, saveDialogValue string) tea.Cmd {
+	return func() tea.Msg {
+		// see FetchLogs
+		api.ClientConnTimeout = 1 * time.Microsecond
+
+		files, _, err := client.AllocFS().List(&alloc, logsDir, nil)
+		if err != nil {
+			return LogsDownloadedMsg{Err: err}
+		}
+		logFiles := getLogFiles(files, taskName, logType)
+		if len(logFiles) == 0 {
+			return LogsDownloadedMsg{Err: fmt.Errorf("no %s log files for task %s", logType.ShortString(), taskName)}
+		}
+
+		w, err := fileio.NewWriter(saveDialogValue, false)
+		if err != nil {
+			return LogsDownloadedMsg{Err: err}
+		}
+		var size int64
+		for _, file := range logFiles {
+			n, err := downloadLogFile(client, alloc, file, w)
+			size += n
+			if err != nil {
+				w.Discard()
+				return LogsDownloadedMsg{Err: err}
+			}
+		}
+		if err = w.Close(); err != nil {
+			os.Remove(w.Path)
+			return LogsDownloadedMsg{Err: err}
+		}
+		return LogsDownloadedMsg{Message: fmt.Sprintf("Downloaded %s of %s to %s", formatMiB(size), strings.ToLower(logType.String()), w.Path)}
+	}
+}
+
+func downloadLogFile(client api.Client, alloc api.Allocation, file *api.AllocFileInfo, w io.Writer) (int64, error) {
+	reader, err := client.AllocFS().Cat(&alloc, path.Join(logsDir, file.Name), nil)
+	if err != nil {
+		return 0, err
 	}
+	defer reader.Close()
+	return io.Copy(w, reader)
 }
 
 func logsAsTable(logs []string, logType LogType) ([]string, []page.Row) {
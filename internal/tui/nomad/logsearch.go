@@ -0,0 +1,182 @@
+package nomad
+
+import (
+	"fmt"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/hashicorp/nomad/api"
+	"github.com/robinovitch61/wander/internal/tui/components/page"
+	"github.com/robinovitch61/wander/internal/tui/formatter"
+	"github.com/robinovitch61/wander/internal/tui/message"
+	"io"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	logsDir            = "alloc/logs"
+	logSearchChunkSize = 1024 * 1024
+)
+
+// LogSearch is a search through every log file of a task, including rotated ones, read a chunk at a time so that
+// progress can be shown and logs too large to load at once can be searched
+type LogSearch struct {
+	ID       int
+	Alloc    api.Allocation
+	TaskName string
+	LogType  LogType
+	Query    string
+
+	BytesRead, TotalBytes int64
+	NumMatches            int
+
+	files       []*api.AllocFileInfo
+	fileIdx     int
+	offset      int64
+	partialLine string
+}
+
+// LogSearchProgressMsg is the result of searching the next chunk of logs. The search continues until Done or Err
+type LogSearchProgressMsg struct {
+	Search  LogSearch
+	Matches []page.Row
+	Done    bool
+	Err     error
+}
+
+// Progress describes how much of the logs have been searched
+func (s LogSearch) Progress(done bool) string {
+	if done {
+		return fmt.Sprintf("(searched %s)", formatMiB(s.TotalBytes))
+	}
+	percent := 0
+	if s.TotalBytes > 0 {
+		percent = int(s.BytesRead * 100 / s.TotalBytes)
+	}
+	return fmt.Sprintf("(searching %d%%, %s of %s)", percent, formatMiB(s.BytesRead), formatMiB(s.TotalBytes))
+}
+
+func formatMiB(bytes int64) string {
+	return fmt.Sprintf("%.1f MiB", float64(bytes)/1024/1024)
+}
+
+// StartLogSearch finds the log files to search, oldest first
+func StartLogSearch(client api.Client, search LogSearch) tea.Cmd {
+	return func() tea.Msg {
+		// see FetchLogs
+		api.ClientConnTimeout = 1 * time.Microsecond
+
+		files, _, err := client.AllocFS().List(&search.Alloc, logsDir, nil)
+		if err != nil {
+			return message.ErrMsg{Err: err}
+		}
+		search.files = getLogFiles(files, search.TaskName, search.LogType)
+		for _, file := range search.files {
+			search.TotalBytes += file.Size
+		}
+
+		columns := []string{fmt.Sprintf("%s matching %q", search.LogType.String(), search.Query)}
+		table := formatter.GetRenderedTableAsString(columns, nil)
+		return PageLoadedMsg{Page: LogSearchPage, TableHeader: table.HeaderRows, LogSearch: search}
+	}
+}
+
+// getLogFiles picks the log files of the task, which are rotated to <task>.<stdout|stderr>.<index>, in index order.
+// Interleaved logs are searched one output after the other
+func getLogFiles(files []*api.AllocFileInfo, taskName string, logType LogType) []*api.AllocFileInfo {
+	var logFiles []*api.AllocFileInfo
+	for _, output := range logType.Split() {
+		logFiles = append(logFiles, getOutputLogFiles(files, taskName, output)...)
+	}
+	return logFiles
+}
+
+func getOutputLogFiles(files []*api.AllocFileInfo, taskName string, logType LogType) []*api.AllocFileInfo {
+	prefix := taskName + "." + logType.ShortString() + "."
+	indexes := make(map[*api.AllocFileInfo]int)
+	var logFiles []*api.AllocFileInfo
+	for _, file := range files {
+		if file.IsDir || !strings.HasPrefix(file.Name, prefix) {
+			continue
+		}
+		idx, err := strconv.Atoi(strings.TrimPrefix(file.Name, prefix))
+		if err != nil {
+			continue
+		}
+		indexes[file] = idx
+		logFiles = append(logFiles, file)
+	}
+	sort.Slice(logFiles, func(x, y int) bool {
+		return indexes[logFiles[x]] < indexes[logFiles[y]]
+	})
+	return logFiles
+}
+
+// ContinueLogSearch searches the next chunk of logs. Lines split across chunks or the files of an output are searched
+// once complete
+func ContinueLogSearch(client api.Client, search LogSearch) tea.Cmd {
+	return func() tea.Msg {
+		if search.fileIdx >= len(search.files) {
+			matches := search.match([]string{search.partialLine})
+			search.NumMatches += len(matches)
+			return LogSearchProgressMsg{Search: search, Matches: matches, Done: true}
+		}
+
+		file := search.files[search.fileIdx]
+		// files are only searched up to their size when the search started, as the latest one may still be growing
+		limit := file.Size - search.offset
+		if limit > logSearchChunkSize {
+			limit = logSearchChunkSize
+		}
+		var data []byte
+		if limit > 0 {
+			reader, err := client.AllocFS().ReadAt(&search.Alloc, path.Join(logsDir, file.Name), search.offset, limit, nil)
+			if err != nil {
+				return LogSearchProgressMsg{Search: search, Err: err}
+			}
+			data, err = io.ReadAll(reader)
+			reader.Close()
+			if err != nil {
+				return LogSearchProgressMsg{Search: search, Err: err}
+			}
+		}
+
+		search.offset += int64(len(data))
+		search.BytesRead += int64(len(data))
+		outputDone := false
+		if len(data) == 0 || search.offset >= file.Size {
+			search.fileIdx++
+			search.offset = 0
+			// a line left unfinished at the end of one output isn't continued in the next
+			outputDone = search.fileIdx >= len(search.files) || !sameLogOutput(file, search.files[search.fileIdx])
+		}
+
+		lines := strings.Split(search.partialLine+string(data), "\n")
+		search.partialLine = ""
+		if !outputDone {
+			search.partialLine = lines[len(lines)-1]
+			lines = lines[:len(lines)-1]
+		}
+		matches := search.match(lines)
+		search.NumMatches += len(matches)
+		return LogSearchProgressMsg{Search: search, Matches: matches}
+	}
+}
+
+// sameLogOutput is true if the log files are rotations of the same output, <task>.<stdout|stderr>.<index>
+func sameLogOutput(x, y *api.AllocFileInfo) bool {
+	return x.Name[:strings.LastIndex(x.Name, ".")] == y.Name[:strings.LastIndex(y.Name, ".")]
+}
+
+func (s LogSearch) match(lines []string) []page.Row {
+	var matches []page.Row
+	for _, line := range lines {
+		cleaned := formatter.CleanLogs(line)
+		if strings.TrimSpace(cleaned) != "" && strings.Contains(cleaned, s.Query) {
+			matches = append(matches, page.Row{Row: cleaned})
+		}
+	}
+	return matches
+}
@@ -0,0 +1,217 @@
+package nomad
+
+import (
+	"fmt"
+	"github.com/robinovitch61/wander/internal/tui/components/page"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// receiveTimeFormat stamps followed log lines with the time they're received, as Nomad doesn't keep the times of lines
+const receiveTimeFormat = "15:04:05.000"
+
+// ReceiveTimeStamp starts a log line received at t
+func ReceiveTimeStamp(t time.Time) string {
+	return t.Format(receiveTimeFormat) + " "
+}
+
+// logTimeFormat is a format of timestamps in log lines, parsed with the first of its layouts that fits
+type logTimeFormat struct {
+	pattern *regexp.Regexp
+	layouts []string
+	// normalize rewrites a match to fit the layouts, if not nil
+	normalize func(string) string
+	// parse is used instead of the layouts, if not nil
+	parse func(string) (time.Time, error)
+	// noYear and noDate fill in the latest year or day that doesn't put the time in the future
+	noYear, noDate bool
+}
+
+// logTimeFormats have dates, so they're preferred to times of day, whichever matches earliest in the line
+var logTimeFormats = []logTimeFormat{
+	{
+		// RFC3339 and similar, e.g. 2006-01-02T15:04:05.000Z or 2006-01-02 15:04:05,000
+		pattern: regexp.MustCompile(`\d{4}-\d{2}-\d{2}[T ]\d{2}:\d{2}(:\d{2}([.,]\d+)?)?(Z|[+-]\d{2}:?\d{2})?`),
+		layouts: []string{
+			"2006-01-02T15:04:05Z07:00",
+			"2006-01-02T15:04:05Z0700",
+			"2006-01-02T15:04:05",
+			"2006-01-02T15:04Z07:00",
+			"2006-01-02T15:04Z0700",
+			"2006-01-02T15:04",
+		},
+		normalize: func(s string) string {
+			return strings.Replace(strings.Replace(s, " ", "T", 1), ",", ".", 1)
+		},
+	},
+	{
+		// Go's log package
+		pattern: regexp.MustCompile(`\d{4}/\d{2}/\d{2} \d{2}:\d{2}:\d{2}(\.\d+)?`),
+		layouts: []string{"2006/01/02 15:04:05"},
+	},
+	{
+		// common log format of web servers
+		pattern: regexp.MustCompile(`\d{2}/[A-Z][a-z]{2}/\d{4}:\d{2}:\d{2}:\d{2} [+-]\d{4}`),
+		layouts: []string{"02/Jan/2006:15:04:05 -0700"},
+	},
+	{
+		// seconds or milliseconds since the epoch in JSON fields, e.g. "ts": 1136214245.123
+		pattern: regexp.MustCompile(`"(ts|time|timestamp)"\s*:\s*(\d{10}|\d{13})(\.\d+)?\b`),
+		parse: func(s string) (time.Time, error) {
+			_, value, _ := strings.Cut(s, ":")
+			secs, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+			if err != nil {
+				return time.Time{}, err
+			}
+			if secs > 1e11 {
+				secs /= 1000
+			}
+			return time.UnixMilli(int64(secs * 1000)), nil
+		},
+	},
+	{
+		// syslog
+		pattern: regexp.MustCompile(`\b[A-Z][a-z]{2} [ \d]\d \d{2}:\d{2}:\d{2}\b`),
+		layouts: []string{"Jan _2 15:04:05"},
+		noYear:  true,
+	},
+}
+
+// timeOfDayFormat is found in lines that have none of logTimeFormats. It also matches the times followed log lines are
+// stamped with when they're received
+var timeOfDayFormat = logTimeFormat{
+	pattern: regexp.MustCompile(`\b\d{2}:\d{2}:\d{2}([.,]\d+)?\b`),
+	layouts: []string{"15:04:05"},
+	normalize: func(s string) string {
+		return strings.Replace(s, ",", ".", 1)
+	},
+	noDate: true,
+}
+
+// find gets the time that the format matches earliest in line, and where it starts
+func (f logTimeFormat) find(line string, now time.Time) (time.Time, int, bool) {
+	for _, loc := range f.pattern.FindAllStringIndex(line, -1) {
+		if t, err := f.parseMatch(line[loc[0]:loc[1]], now); err == nil {
+			return t, loc[0], true
+		}
+	}
+	return time.Time{}, -1, false
+}
+
+func (f logTimeFormat) parseMatch(s string, now time.Time) (time.Time, error) {
+	if f.parse != nil {
+		return f.parse(s)
+	}
+	if f.normalize != nil {
+		s = f.normalize(s)
+	}
+	var err error
+	for _, layout := range f.layouts {
+		var t time.Time
+		if t, err = time.ParseInLocation(layout, s, now.Location()); err == nil {
+			return withMissingDate(t, now, f.noYear, f.noDate), nil
+		}
+	}
+	return time.Time{}, err
+}
+
+// withMissingDate fills in the year or date of t if it doesn't have one, as the latest that doesn't put t more than a
+// little in the future, as clocks differ
+func withMissingDate(t, now time.Time, noYear, noDate bool) time.Time {
+	switch {
+	case noDate:
+		t = time.Date(now.Year(), now.Month(), now.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+		if t.After(now.Add(time.Hour)) {
+			t = t.AddDate(0, 0, -1)
+		}
+	case noYear:
+		t = t.AddDate(now.Year(), 0, 0)
+		if t.After(now.Add(24 * time.Hour)) {
+			t = t.AddDate(-1, 0, 0)
+		}
+	}
+	return t
+}
+
+// findLogTime gets the time in a log line, preferring timestamps with dates to times of day
+func findLogTime(line string, now time.Time) (time.Time, bool) {
+	found, earliest := time.Time{}, -1
+	for _, f := range logTimeFormats {
+		if t, start, ok := f.find(line, now); ok && (earliest < 0 || start < earliest) {
+			found, earliest = t, start
+		}
+	}
+	if earliest >= 0 {
+		return found, true
+	}
+	t, _, ok := timeOfDayFormat.find(line, now)
+	return t, ok
+}
+
+// LogTimes detects the times of log rows from the timestamps in them, remembering them as rows are filtered on every
+// change to the logs being viewed
+type LogTimes struct {
+	times map[string]time.Time
+	// maxCached is the most lines remembered before forgetting them all, as for LogLevels
+	maxCached int
+}
+
+// NewLogTimes remembers the times of about as many lines as are kept, maxLines, or of every line if it's 0
+func NewLogTimes(maxLines int) LogTimes {
+	return LogTimes{times: make(map[string]time.Time), maxCached: logLinesCached(maxLines)}
+}
+
+// Of gets the time of a log row. Rows with a key have the line as it was logged as the key, so that's used before the
+// rest of the row, e.g. the time the line was received
+func (l LogTimes) Of(row page.Row) (time.Time, bool) {
+	if row.Key != "" {
+		if t, ok := l.of(row.Key); ok {
+			return t, true
+		}
+	}
+	return l.of(row.Row)
+}
+
+func (l LogTimes) of(line string) (time.Time, bool) {
+	if t, exists := l.times[line]; exists {
+		return t, !t.IsZero()
+	}
+	t, ok := findLogTime(line, time.Now())
+	if l.times != nil {
+		if l.maxCached > 0 && len(l.times) >= l.maxCached {
+			clear(l.times)
+		}
+		l.times[line] = t
+	}
+	return t, ok
+}
+
+// ParseLogTime parses a time to jump to in logs, either a timestamp like those in logs, a time of day like 15:04 or a
+// duration before now like 10m
+func ParseLogTime(s string, now time.Time) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	if d, err := time.ParseDuration(strings.TrimPrefix(s, "-")); err == nil {
+		return now.Add(-d), nil
+	}
+	if t, err := time.ParseInLocation("15:04", s, now.Location()); err == nil {
+		return withMissingDate(t, now, false, true), nil
+	}
+	if t, ok := findLogTime(s, now); ok {
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("invalid time %q, e.g. 15:04, 2006-01-02T15:04:05Z or 10m", s)
+}
+
+// ParseLogWindow parses how far back to show logs from, either a number of minutes or a duration like 1h30m
+func ParseLogWindow(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	if minutes, err := strconv.Atoi(s); err == nil && minutes >= 0 {
+		return time.Duration(minutes) * time.Minute, nil
+	}
+	if d, err := time.ParseDuration(s); err == nil && d >= 0 {
+		return d, nil
+	}
+	return 0, fmt.Errorf("invalid number of minutes %q", s)
+}
@@ -0,0 +1,52 @@
+package nomad
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/hashicorp/nomad/api"
+	"github.com/robinovitch61/wander/internal/tui/components/page"
+	"github.com/robinovitch61/wander/internal/tui/formatter"
+	"github.com/robinovitch61/wander/internal/tui/message"
+	"sort"
+)
+
+const allNamespaces = "*"
+
+func FetchNamespaces(client api.Client) tea.Cmd {
+	return func() tea.Msg {
+		namespaces, _, err := client.Namespaces().List(nil)
+		if err != nil {
+			return message.ErrMsg{Err: err}
+		}
+
+		sort.Slice(namespaces, func(x, y int) bool {
+			return namespaces[x].Name < namespaces[y].Name
+		})
+
+		tableHeader, allPageData := namespacesAsTable(namespaces)
+		return PageLoadedMsg{Page: NamespacesPage, TableHeader: tableHeader, AllPageRows: allPageData}
+	}
+}
+
+func namespacesAsTable(namespaces []*api.Namespace) ([]string, []page.Row) {
+	columns := []string{"Namespace", "Description", "Quota"}
+
+	namespaceRows := [][]string{{allNamespaces, "All namespaces", "-"}}
+	keys := []string{allNamespaces}
+	for _, namespace := range namespaces {
+		quota := namespace.Quota
+		if quota == "" {
+			quota = "-"
+		}
+		namespaceRows = append(namespaceRows, []string{namespace.Name, namespace.Description, quota})
+		keys = append(keys, namespace.Name)
+	}
+
+	table := formatter.GetRenderedTableAsString(columns, namespaceRows)
+
+	var rows []page.Row
+	for idx, row := range table.ContentRows {
+		rows = append(rows, page.Row{Key: keys[idx], Row: row})
+	}
+
+	return table.HeaderRows, rows
+}
@@ -0,0 +1,167 @@
+package nomad
+
+import (
+	"fmt"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/hashicorp/nomad/api"
+	"github.com/robinovitch61/wander/internal/tui/components/page"
+	"github.com/robinovitch61/wander/internal/tui/formatter"
+	"github.com/robinovitch61/wander/internal/tui/message"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type nodeResourceUsage struct {
+	CpuMhz, MemoryMB int64
+}
+
+func FetchNodes(client api.Client, columns []string) tea.Cmd {
+	return func() tea.Msg {
+		// resources are only needed for the CPU and Memory columns, which sum the allocations on each node
+		var nodeOpts *api.QueryOptions
+		var allocations []*api.AllocationListStub
+		if showsNodeResources(columns) {
+			nodeOpts = &api.QueryOptions{Params: map[string]string{"resources": "true"}}
+			var err error
+			allocations, _, err = client.Allocations().List(&api.QueryOptions{
+				Params: map[string]string{"resources": "true"},
+				Filter: `ClientStatus == "running" or ClientStatus == "pending"`,
+			})
+			if err != nil {
+				return message.ErrMsg{Err: err}
+			}
+		}
+
+		nodeResults, _, err := client.Nodes().List(nodeOpts)
+		if err != nil {
+			return message.ErrMsg{Err: err}
+		}
+
+		sort.Slice(nodeResults, func(x, y int) bool {
+			firstNode := nodeResults[x]
+			secondNode := nodeResults[y]
+			if firstNode.Name == secondNode.Name {
+				return firstNode.ID < secondNode.ID
+			}
+			return firstNode.Name < secondNode.Name
+		})
+
+		tableHeader, allPageData := nodesAsTable(nodeResults, getAllocatedByNode(allocations), columns)
+		return PageLoadedMsg{Page: NodesPage, TableHeader: tableHeader, AllPageRows: allPageData}
+	}
+}
+
+func showsNodeResources(columns []string) bool {
+	for _, col := range columns {
+		if col == "CPU" || col == "Memory" {
+			return true
+		}
+	}
+	return false
+}
+
+// getAllocatedByNode sums the resources of all allocations that are currently placed on each node
+func getAllocatedByNode(allocations []*api.AllocationListStub) map[string]nodeResourceUsage {
+	allocatedByNode := make(map[string]nodeResourceUsage)
+	for _, alloc := range allocations {
+		if alloc.AllocatedResources == nil || alloc.ClientStatus != "running" && alloc.ClientStatus != "pending" {
+			continue
+		}
+		usage := allocatedByNode[alloc.NodeID]
+		for _, taskResources := range alloc.AllocatedResources.Tasks {
+			if taskResources == nil {
+				continue
+			}
+			usage.CpuMhz += taskResources.Cpu.CpuShares
+			usage.MemoryMB += taskResources.Memory.MemoryMB
+		}
+		allocatedByNode[alloc.NodeID] = usage
+	}
+	return allocatedByNode
+}
+
+func getNodeTotal(row *api.NodeListStub) nodeResourceUsage {
+	var total nodeResourceUsage
+	if row.NodeResources != nil {
+		total.CpuMhz = row.NodeResources.Cpu.CpuShares
+		total.MemoryMB = row.NodeResources.Memory.MemoryMB
+	}
+	if row.ReservedResources != nil {
+		total.CpuMhz -= int64(row.ReservedResources.Cpu.CpuShares)
+		total.MemoryMB -= int64(row.ReservedResources.Memory.MemoryMB)
+	}
+	return total
+}
+
+func formatAllocatedOfTotal(allocated, total int64, unit string) string {
+	if total <= 0 {
+		return "-"
+	}
+	perc := float64(allocated) / float64(total) * 100
+	return fmt.Sprintf("%d/%d %s (%.0f%%)", allocated, total, unit, perc)
+}
+
+func getEligibility(row *api.NodeListStub) string {
+	if row.SchedulingEligibility == api.NodeSchedulingEligible {
+		return "eligible"
+	}
+	return "ineligible"
+}
+
+func getNodeRowFromColumns(row *api.NodeListStub, allocated nodeResourceUsage, columns []string) []string {
+	total := getNodeTotal(row)
+	knownColMap := map[string]string{
+		"ID":          formatter.ShortAllocID(row.ID),
+		"Name":        row.Name,
+		"Address":     row.Address,
+		"Datacenter":  row.Datacenter,
+		"Node Pool":   row.NodePool,
+		"Class":       row.NodeClass,
+		"Status":      row.Status,
+		"Eligibility": getEligibility(row),
+		"Drain":       strconv.FormatBool(row.Drain),
+		"Version":     row.Version,
+		"CPU":         formatAllocatedOfTotal(allocated.CpuMhz, total.CpuMhz, "MHz"),
+		"Memory":      formatAllocatedOfTotal(allocated.MemoryMB, total.MemoryMB, "MiB"),
+	}
+
+	var rowEntries []string
+	for _, col := range columns {
+		if v, exists := knownColMap[col]; exists {
+			if v == "" {
+				v = "-"
+			}
+			rowEntries = append(rowEntries, v)
+		} else {
+			rowEntries = append(rowEntries, "-")
+		}
+	}
+	return rowEntries
+}
+
+func nodesAsTable(nodes []*api.NodeListStub, allocatedByNode map[string]nodeResourceUsage, columns []string) ([]string, []page.Row) {
+	var nodeRows [][]string
+	var keys []string
+	for _, row := range nodes {
+		nodeRows = append(nodeRows, getNodeRowFromColumns(row, allocatedByNode[row.ID], columns))
+		keys = append(keys, toNodeKey(row))
+	}
+	table := formatter.GetRenderedTableAsString(columns, nodeRows)
+
+	var rows []page.Row
+	for idx, row := range table.ContentRows {
+		rows = append(rows, page.Row{Key: keys[idx], Row: row})
+	}
+
+	return table.HeaderRows, rows
+}
+
+func toNodeKey(node *api.NodeListStub) string {
+	return node.ID + keySeparator + node.Name
+}
+
+func NodeIDAndNameFromKey(key string) (string, string) {
+	split := strings.Split(key, keySeparator)
+	return split[0], split[1]
+}
@@ -0,0 +1,68 @@
+package nomad
+
+import (
+	"encoding/json"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/hashicorp/nomad/api"
+	"github.com/robinovitch61/wander/internal/tui/components/page"
+	"github.com/robinovitch61/wander/internal/tui/formatter"
+	"github.com/robinovitch61/wander/internal/tui/message"
+)
+
+type nodeSpecSection struct {
+	title string
+	value interface{}
+}
+
+func FetchNodeSpec(client api.Client, nodeID string) tea.Cmd {
+	return func() tea.Msg {
+		node, _, err := client.Nodes().Info(nodeID, nil)
+		if err != nil {
+			return message.ErrMsg{Err: err}
+		}
+
+		sections := []nodeSpecSection{
+			{"Node", map[string]interface{}{
+				"ID":                    node.ID,
+				"Name":                  node.Name,
+				"Datacenter":            node.Datacenter,
+				"NodePool":              node.NodePool,
+				"NodeClass":             node.NodeClass,
+				"HTTPAddr":              node.HTTPAddr,
+				"Status":                node.Status,
+				"StatusDescription":     node.StatusDescription,
+				"SchedulingEligibility": node.SchedulingEligibility,
+				"Drain":                 node.Drain,
+				"DrainStrategy":         node.DrainStrategy,
+			}},
+			{"Attributes", node.Attributes},
+			{"Meta", node.Meta},
+			{"Drivers", node.Drivers},
+			{"Host Volumes", node.HostVolumes},
+			{"Host Networks", node.HostNetworks},
+			{"Reserved Resources", node.ReservedResources},
+		}
+
+		var nodeSpecPageData []page.Row
+		for i, section := range sections {
+			if i > 0 {
+				nodeSpecPageData = append(nodeSpecPageData, page.Row{Key: "", Row: ""})
+			}
+			nodeSpecPageData = append(nodeSpecPageData, page.Row{Key: "", Row: section.title})
+
+			sectionBytes, err := json.Marshal(section.value)
+			if err != nil {
+				return message.ErrMsg{Err: err}
+			}
+			for _, row := range formatter.PrettyJsonStringAsLines(string(sectionBytes)) {
+				nodeSpecPageData = append(nodeSpecPageData, page.Row{Key: "", Row: row})
+			}
+		}
+
+		return PageLoadedMsg{
+			Page:        NodeSpecPage,
+			TableHeader: []string{},
+			AllPageRows: nodeSpecPageData,
+		}
+	}
+}
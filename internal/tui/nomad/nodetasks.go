@@ -0,0 +1,62 @@
+package nomad
+
+import (
+	"encoding/json"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/hashicorp/nomad/api"
+	"github.com/robinovitch61/wander/internal/tui/message"
+	"sort"
+)
+
+func FetchTasksForNode(client api.Client, nodeID string, columns []string) tea.Cmd {
+	return func() tea.Msg {
+		allocationsForNode, _, err := client.Nodes().Allocations(nodeID, nil)
+		if err != nil {
+			return message.ErrMsg{Err: err}
+		}
+
+		var nodeTaskRowEntries []taskRowEntry
+		for _, alloc := range allocationsForNode {
+			allocAsJSON, err := json.Marshal(alloc)
+			if err != nil {
+				return message.ErrMsg{Err: err}
+			}
+
+			for taskName, task := range alloc.TaskStates {
+				nodeTaskRowEntries = append(nodeTaskRowEntries, taskRowEntry{
+					FullAllocationAsJSON: string(allocAsJSON),
+					NodeID:               alloc.NodeID,
+					JobID:                alloc.JobID,
+					ID:                   alloc.ID,
+					TaskGroup:            alloc.TaskGroup,
+					Name:                 alloc.Name,
+					TaskName:             taskName,
+					State:                task.State,
+					StartedAt:            task.StartedAt.UTC(),
+					FinishedAt:           task.FinishedAt.UTC(),
+				})
+			}
+		}
+
+		sort.Slice(nodeTaskRowEntries, func(x, y int) bool {
+			firstTask := nodeTaskRowEntries[x]
+			secondTask := nodeTaskRowEntries[y]
+			if firstTask.JobID == secondTask.JobID {
+				if firstTask.TaskName == secondTask.TaskName {
+					if firstTask.Name == secondTask.Name {
+						if firstTask.StartedAt.Equal(secondTask.StartedAt) {
+							return firstTask.ID > secondTask.ID
+						}
+						return firstTask.StartedAt.After(secondTask.StartedAt)
+					}
+					return firstTask.Name < secondTask.Name
+				}
+				return firstTask.TaskName < secondTask.TaskName
+			}
+			return firstTask.JobID < secondTask.JobID
+		})
+
+		tableHeader, allPageData := tasksAsTable(nodeTaskRowEntries, columns)
+		return PageLoadedMsg{Page: NodeTasksPage, TableHeader: tableHeader, AllPageRows: allPageData}
+	}
+}
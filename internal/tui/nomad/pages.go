@@ -35,6 +35,43 @@ const (
 	LogsPage
 	LoglinePage
 	StatsPage
+	NodesPage
+	NodeTasksPage
+	NodeSpecPage
+	JobDeploymentsPage
+	AllDeploymentsPage
+	JobEvaluationsPage
+	JobEvaluationPage
+	JobVersionsPage
+	JobVersionDiffPage
+	SignalPage
+	NamespacesPage
+	ProfilesPage
+	RegionsPage
+	VariablesPage
+	VariablePage
+	ServicesPage
+	ServicePage
+	VolumesPage
+	VolumePage
+	PluginsPage
+	HostVolumesPage
+	LogSearchPage
+	AllocFilesPage
+	AllocFilePage
+	JobLogsPage
+	JobLogAllocsPage
+)
+
+// Mode is the top level view that the user is browsing from, which determines where back navigation ends up
+type Mode int8
+
+const (
+	JobsMode Mode = iota
+	AllTasksMode
+	NodesMode
+	ServicesMode
+	VolumesMode
 )
 
 func GetAllPageConfigs(width, height int, compactTables bool) map[Page]page.Config {
@@ -116,16 +153,171 @@ func GetAllPageConfigs(width, height int, compactTables bool) map[Page]page.Conf
 			LoadingString:    LogsPage.LoadingString(),
 			SelectionEnabled: true, WrapText: false, RequestInput: false,
 		},
+		LogSearchPage: {
+			Width: width, Height: height,
+			LoadingString:    LogSearchPage.LoadingString(),
+			SelectionEnabled: true, WrapText: false, RequestInput: false,
+		},
+		JobLogsPage: {
+			Width: width, Height: height,
+			LoadingString:    JobLogsPage.LoadingString(),
+			SelectionEnabled: true, WrapText: false, RequestInput: false,
+		},
+		JobLogAllocsPage: {
+			Width: width, Height: height,
+			LoadingString:    JobLogAllocsPage.LoadingString(),
+			SelectionEnabled: true, WrapText: false, RequestInput: false,
+			CompactTableContent: compactTables,
+		},
 		LoglinePage: {
 			Width: width, Height: height,
 			LoadingString:    LoglinePage.LoadingString(),
 			SelectionEnabled: false, WrapText: true, RequestInput: false,
 		},
+		AllocFilesPage: {
+			Width: width, Height: height,
+			LoadingString:    AllocFilesPage.LoadingString(),
+			SelectionEnabled: true, WrapText: false, RequestInput: false,
+			CompactTableContent: compactTables,
+		},
+		AllocFilePage: {
+			Width: width, Height: height,
+			LoadingString:    AllocFilePage.LoadingString(),
+			SelectionEnabled: false, WrapText: false, RequestInput: false,
+		},
 		StatsPage: {
 			Width: width, Height: height,
 			LoadingString:    StatsPage.LoadingString(),
 			SelectionEnabled: false, WrapText: false, RequestInput: false,
 		},
+		NodesPage: {
+			Width: width, Height: height,
+			LoadingString:    NodesPage.LoadingString(),
+			SelectionEnabled: true, WrapText: false, RequestInput: false,
+			CompactTableContent:      compactTables,
+			ViewportConditionalStyle: constants.NodesTableStatusStyles,
+		},
+		NodeTasksPage: {
+			Width: width, Height: height,
+			LoadingString:    NodeTasksPage.LoadingString(),
+			SelectionEnabled: true, WrapText: false, RequestInput: false,
+			CompactTableContent:      compactTables,
+			ViewportConditionalStyle: constants.TasksTableStatusStyles,
+		},
+		NodeSpecPage: {
+			Width: width, Height: height,
+			LoadingString:    NodeSpecPage.LoadingString(),
+			SelectionEnabled: false, WrapText: true, RequestInput: false,
+		},
+		JobDeploymentsPage: {
+			Width: width, Height: height,
+			LoadingString:    JobDeploymentsPage.LoadingString(),
+			SelectionEnabled: true, WrapText: false, RequestInput: false,
+			CompactTableContent:      compactTables,
+			ViewportConditionalStyle: constants.DeploymentsTableStatusStyles,
+		},
+		AllDeploymentsPage: {
+			Width: width, Height: height,
+			LoadingString:    AllDeploymentsPage.LoadingString(),
+			SelectionEnabled: true, WrapText: false, RequestInput: false,
+			CompactTableContent:      compactTables,
+			ViewportConditionalStyle: constants.DeploymentsTableStatusStyles,
+		},
+		JobEvaluationsPage: {
+			Width: width, Height: height,
+			LoadingString:    JobEvaluationsPage.LoadingString(),
+			SelectionEnabled: true, WrapText: false, RequestInput: false,
+			CompactTableContent:      compactTables,
+			ViewportConditionalStyle: constants.EvaluationsTableStatusStyles,
+		},
+		JobEvaluationPage: {
+			Width: width, Height: height,
+			LoadingString:    JobEvaluationPage.LoadingString(),
+			SelectionEnabled: false, WrapText: true, RequestInput: false,
+		},
+		JobVersionsPage: {
+			Width: width, Height: height,
+			LoadingString:    JobVersionsPage.LoadingString(),
+			SelectionEnabled: true, WrapText: false, RequestInput: false,
+			CompactTableContent: compactTables,
+		},
+		JobVersionDiffPage: {
+			Width: width, Height: height,
+			LoadingString:    JobVersionDiffPage.LoadingString(),
+			SelectionEnabled: false, WrapText: true, RequestInput: false,
+			ViewportPrefixStyle: constants.JobVersionDiffStyles,
+		},
+		SignalPage: {
+			Width: width, Height: height,
+			LoadingString:    SignalPage.LoadingString(),
+			SelectionEnabled: true, WrapText: false, RequestInput: false,
+		},
+		NamespacesPage: {
+			Width: width, Height: height,
+			LoadingString:    NamespacesPage.LoadingString(),
+			SelectionEnabled: true, WrapText: false, RequestInput: false,
+			CompactTableContent: compactTables,
+		},
+		ProfilesPage: {
+			Width: width, Height: height,
+			LoadingString:    ProfilesPage.LoadingString(),
+			SelectionEnabled: true, WrapText: false, RequestInput: false,
+			CompactTableContent: compactTables,
+		},
+		RegionsPage: {
+			Width: width, Height: height,
+			LoadingString:    RegionsPage.LoadingString(),
+			SelectionEnabled: true, WrapText: false, RequestInput: false,
+			CompactTableContent: compactTables,
+		},
+		VariablesPage: {
+			Width: width, Height: height,
+			LoadingString:    VariablesPage.LoadingString(),
+			SelectionEnabled: true, WrapText: false, RequestInput: false,
+			CompactTableContent: compactTables,
+		},
+		VariablePage: {
+			Width: width, Height: height,
+			LoadingString:    VariablePage.LoadingString(),
+			SelectionEnabled: true, WrapText: false, RequestInput: false,
+			CompactTableContent: compactTables,
+		},
+		ServicesPage: {
+			Width: width, Height: height,
+			LoadingString:    ServicesPage.LoadingString(),
+			SelectionEnabled: true, WrapText: false, RequestInput: false,
+			CompactTableContent: compactTables,
+		},
+		ServicePage: {
+			Width: width, Height: height,
+			LoadingString:    ServicePage.LoadingString(),
+			SelectionEnabled: true, WrapText: false, RequestInput: false,
+			CompactTableContent: compactTables,
+		},
+		VolumesPage: {
+			Width: width, Height: height,
+			LoadingString:    VolumesPage.LoadingString(),
+			SelectionEnabled: true, WrapText: false, RequestInput: false,
+			CompactTableContent: compactTables,
+		},
+		VolumePage: {
+			Width: width, Height: height,
+			LoadingString:    VolumePage.LoadingString(),
+			SelectionEnabled: true, WrapText: false, RequestInput: false,
+			CompactTableContent: compactTables,
+		},
+		PluginsPage: {
+			Width: width, Height: height,
+			LoadingString:    PluginsPage.LoadingString(),
+			SelectionEnabled: true, WrapText: false, RequestInput: false,
+			CompactTableContent: compactTables,
+		},
+		HostVolumesPage: {
+			Width: width, Height: height,
+			LoadingString:    HostVolumesPage.LoadingString(),
+			SelectionEnabled: true, WrapText: false, RequestInput: false,
+			CompactTableContent: compactTables,
+		},
 	}
 }
 
@@ -140,7 +332,7 @@ func (p Page) DoesLoad() bool {
 }
 
 func (p Page) DoesReload() bool {
-	noReloadPages := []Page{LoglinePage, JobEventsPage, JobEventPage, AllocEventsPage, AllocEventPage, AllEventsPage, AllEventPage, ExecPage}
+	noReloadPages := []Page{LoglinePage, JobEventsPage, JobEventPage, AllocEventsPage, AllocEventPage, AllEventsPage, AllEventPage, ExecPage, SignalPage, ProfilesPage}
 	for _, noReloadPage := range noReloadPages {
 		if noReloadPage == p {
 			return false
@@ -150,7 +342,7 @@ func (p Page) DoesReload() bool {
 }
 
 func (p Page) ShowsTasks() bool {
-	taskPages := []Page{AllTasksPage, JobTasksPage}
+	taskPages := []Page{AllTasksPage, JobTasksPage, NodeTasksPage, VolumePage}
 	for _, taskPage := range taskPages {
 		if taskPage == p {
 			return true
@@ -163,19 +355,38 @@ func (p Page) CanBeFirstPage() bool {
 	return p == JobsPage || p == AllTasksPage
 }
 
+// IsModeRoot is true for the pages that are switched between with the mode key bindings
+func (p Page) IsModeRoot() bool {
+	return p == JobsPage || p == AllTasksPage || p == NodesPage || p == ServicesPage || p == VolumesPage
+}
+
+func (p Page) ShowsDeployments() bool {
+	return p == JobDeploymentsPage || p == AllDeploymentsPage
+}
+
 func (p Page) doesUpdate() bool {
 	noUpdatePages := []Page{
-		LoglinePage,     // doesn't load
-		ExecPage,        // doesn't reload
-		LogsPage,        // currently makes scrolling impossible - solve in https://github.com/robinovitch61/wander/issues/1
-		JobSpecPage,     // would require changes to make scrolling possible
-		AllocSpecPage,   // would require changes to make scrolling possible
-		JobEventsPage,   // constant connection, streams data
-		JobEventPage,    // doesn't load
-		AllocEventsPage, // constant connection, streams data
-		AllocEventPage,  // doesn't load
-		AllEventsPage,   // constant connection, streams data
-		AllEventPage,    // doesn't load
+		LoglinePage,        // doesn't load
+		ExecPage,           // doesn't reload
+		LogsPage,           // currently makes scrolling impossible - solve in https://github.com/robinovitch61/wander/issues/1
+		LogSearchPage,      // searches in chunks until done
+		JobLogsPage,        // constant connection, streams data
+		JobLogAllocsPage,   // only changes when toggling allocations
+		JobSpecPage,        // would require changes to make scrolling possible
+		AllocSpecPage,      // would require changes to make scrolling possible
+		NodeSpecPage,       // would require changes to make scrolling possible
+		JobEvaluationPage,  // would require changes to make scrolling possible
+		JobVersionDiffPage, // would require changes to make scrolling possible
+		AllocFilePage,      // would require changes to make scrolling possible
+		JobEventsPage,      // constant connection, streams data
+		JobEventPage,       // doesn't load
+		AllocEventsPage,    // constant connection, streams data
+		AllocEventPage,     // doesn't load
+		AllEventsPage,      // constant connection, streams data
+		AllEventPage,       // doesn't load
+		SignalPage,         // doesn't reload
+		ProfilesPage,       // doesn't reload
+		HostVolumesPage,    // rarely changes, and needs a request per node
 	}
 	for _, noUpdatePage := range noUpdatePages {
 		if noUpdatePage == p {
@@ -215,8 +426,60 @@ func (p Page) String() string {
 		return "logs"
 	case LoglinePage:
 		return "log"
+	case LogSearchPage:
+		return "log search"
+	case AllocFilesPage:
+		return "files"
+	case AllocFilePage:
+		return "file"
+	case JobLogsPage:
+		return "job logs"
+	case JobLogAllocsPage:
+		return "log allocations"
 	case StatsPage:
 		return "stats"
+	case NodesPage:
+		return "nodes"
+	case NodeTasksPage:
+		return "tasks"
+	case NodeSpecPage:
+		return "node spec"
+	case JobDeploymentsPage:
+		return "deployments"
+	case AllDeploymentsPage:
+		return "all deployments"
+	case JobEvaluationsPage:
+		return "evaluations"
+	case JobEvaluationPage:
+		return "evaluation"
+	case JobVersionsPage:
+		return "versions"
+	case JobVersionDiffPage:
+		return "diff"
+	case SignalPage:
+		return "signals"
+	case NamespacesPage:
+		return "namespaces"
+	case ProfilesPage:
+		return "profiles"
+	case RegionsPage:
+		return "regions"
+	case VariablesPage:
+		return "variables"
+	case VariablePage:
+		return "variable"
+	case ServicesPage:
+		return "services"
+	case ServicePage:
+		return "service"
+	case VolumesPage:
+		return "volumes"
+	case VolumePage:
+		return "volume"
+	case PluginsPage:
+		return "plugins"
+	case HostVolumesPage:
+		return "host volumes"
 	}
 	return "unknown"
 }
@@ -241,18 +504,60 @@ func (p Page) Forward() Page {
 		return LogsPage
 	case LogsPage:
 		return LoglinePage
+	case NodesPage:
+		return NodeTasksPage
+	case NodeTasksPage:
+		return LogsPage
+	case JobEvaluationsPage:
+		return JobEvaluationPage
+	case JobVersionsPage:
+		return JobVersionDiffPage
+	case VariablesPage:
+		return VariablePage
+	case ServicesPage:
+		return ServicePage
+	case ServicePage:
+		return LogsPage
+	case VolumesPage:
+		return VolumePage
+	case VolumePage:
+		return LogsPage
+	case AllocFilesPage:
+		return AllocFilePage
 	}
 	return p
 }
 
-func returnToTasksPage(inJobsMode bool) Page {
-	if inJobsMode {
-		return JobTasksPage
+func returnToTasksPage(mode Mode) Page {
+	switch mode {
+	case AllTasksMode:
+		return AllTasksPage
+	case NodesMode:
+		return NodeTasksPage
+	case ServicesMode:
+		// logs are viewed from the service instance that the task registered
+		return ServicePage
+	case VolumesMode:
+		return VolumePage
 	}
-	return AllTasksPage
+	return JobTasksPage
 }
 
-func (p Page) Backward(inJobsMode bool) Page {
+func ModeRootPage(mode Mode) Page {
+	switch mode {
+	case AllTasksMode:
+		return AllTasksPage
+	case NodesMode:
+		return NodesPage
+	case ServicesMode:
+		return ServicesPage
+	case VolumesMode:
+		return VolumesPage
+	}
+	return JobsPage
+}
+
+func (p Page) Backward(mode Mode) Page {
 	switch p {
 	case JobSpecPage:
 		return JobsPage
@@ -263,7 +568,7 @@ func (p Page) Backward(inJobsMode bool) Page {
 	case JobMetaPage:
 		return JobsPage
 	case AllocEventsPage:
-		return returnToTasksPage(inJobsMode)
+		return returnToTasksPage(mode)
 	case AllocEventPage:
 		return AllocEventsPage
 	case AllEventsPage:
@@ -273,15 +578,61 @@ func (p Page) Backward(inJobsMode bool) Page {
 	case JobTasksPage:
 		return JobsPage
 	case ExecPage:
-		return returnToTasksPage(inJobsMode)
+		return returnToTasksPage(mode)
 	case AllocSpecPage:
-		return returnToTasksPage(inJobsMode)
+		return returnToTasksPage(mode)
 	case LogsPage:
-		return returnToTasksPage(inJobsMode)
+		return returnToTasksPage(mode)
 	case LoglinePage:
 		return LogsPage
+	case LogSearchPage:
+		return LogsPage
+	case AllocFilesPage:
+		return returnToTasksPage(mode)
+	case JobLogsPage:
+		return JobTasksPage
+	case JobLogAllocsPage:
+		return JobLogsPage
+	case AllocFilePage:
+		return AllocFilesPage
 	case StatsPage:
-		return returnToTasksPage(inJobsMode)
+		return returnToTasksPage(mode)
+	case NodeTasksPage:
+		return NodesPage
+	case NodeSpecPage:
+		return NodesPage
+	case JobDeploymentsPage:
+		return JobsPage
+	case AllDeploymentsPage:
+		return JobsPage
+	case JobEvaluationsPage:
+		return JobsPage
+	case JobEvaluationPage:
+		return JobEvaluationsPage
+	case JobVersionsPage:
+		return JobsPage
+	case JobVersionDiffPage:
+		return JobVersionsPage
+	case SignalPage:
+		return returnToTasksPage(mode)
+	case NamespacesPage:
+		return ModeRootPage(mode)
+	case ProfilesPage:
+		return ModeRootPage(mode)
+	case RegionsPage:
+		return ModeRootPage(mode)
+	case VariablesPage:
+		return ModeRootPage(mode)
+	case VariablePage:
+		return VariablesPage
+	case ServicePage:
+		return ServicesPage
+	case VolumePage:
+		return VolumesPage
+	case PluginsPage:
+		return VolumesPage
+	case HostVolumesPage:
+		return VolumesPage
 	}
 	return p
 }
@@ -301,40 +652,137 @@ func namespaceFilterPrefix(namespace string) string {
 	return fmt.Sprintf("Namespace %s", style.Bold.Render(namespace))
 }
 
-func (p Page) GetFilterPrefix(namespace, jobID, taskName, allocName, allocID string, eventTopics Topics, eventNamespace string) string {
+func jobVersionsFilterPrefix(jobID, markedJobVersion string) string {
+	prefix := fmt.Sprintf("Versions for Job %s", style.Bold.Render(jobID))
+	if markedJobVersion != "" {
+		prefix += fmt.Sprintf(" (diff against version %s)", markedJobVersion)
+	}
+	return prefix
+}
+
+func jobLogsFilterPrefix(jobID, taskGroup string) string {
+	if taskGroup == "" {
+		return fmt.Sprintf("Logs for Job %s", style.Bold.Render(jobID))
+	}
+	return fmt.Sprintf("Logs for Task Group %s in Job %s", style.Bold.Render(taskGroup), style.Bold.Render(jobID))
+}
+
+func regionFilterPrefix(region string) string {
+	if region == AllRegions {
+		return style.Bold.Render("all regions")
+	}
+	if region == "" {
+		return style.Bold.Render("default")
+	}
+	return style.Bold.Render(region)
+}
+
+// FilterPrefixInfo is what the filter prefix of a page describes, e.g. the job or task being viewed
+type FilterPrefixInfo struct {
+	Namespace, Profile, Region                     string
+	JobID, TaskGroup, TaskName, AllocName, AllocID string
+	NodeName, EvalID                               string
+	VariablePath, VariableNamespace                string
+	ServiceName, VolumeID, AllocFilePath           string
+	MarkedJobVersion                               string
+	DiffFromVersion, DiffToVersion                 uint64
+	MinLogLevel                                    LogLevel
+	EventTopics                                    Topics
+	EventNamespace                                 string
+}
+
+func (p Page) GetFilterPrefix(info FilterPrefixInfo) string {
 	switch p {
 	case JobsPage:
-		return fmt.Sprintf("Jobs in %s", namespaceFilterPrefix(namespace))
+		if info.Region == AllRegions {
+			return fmt.Sprintf("Jobs in %s in %s", namespaceFilterPrefix(info.Namespace), regionFilterPrefix(info.Region))
+		}
+		return fmt.Sprintf("Jobs in %s", namespaceFilterPrefix(info.Namespace))
 	case AllTasksPage:
-		return fmt.Sprintf("All Tasks in %s", namespaceFilterPrefix(namespace))
+		return fmt.Sprintf("All Tasks in %s", namespaceFilterPrefix(info.Namespace))
 	case JobSpecPage:
-		return fmt.Sprintf("Spec for Job %s", style.Bold.Render(jobID))
+		return fmt.Sprintf("Spec for Job %s", style.Bold.Render(info.JobID))
 	case JobEventsPage:
-		return fmt.Sprintf("Events for Job %s (%s)", style.Bold.Render(jobID), getTopicNames(eventTopics))
+		return fmt.Sprintf("Events for Job %s (%s)", style.Bold.Render(info.JobID), getTopicNames(info.EventTopics))
 	case JobEventPage:
-		return fmt.Sprintf("Event for Job %s", style.Bold.Render(jobID))
+		return fmt.Sprintf("Event for Job %s", style.Bold.Render(info.JobID))
 	case JobMetaPage:
-		return fmt.Sprintf("Meta for Job %s", jobID)
+		return fmt.Sprintf("Meta for Job %s", info.JobID)
 	case AllocEventsPage:
-		return fmt.Sprintf("Events for Allocation %s", allocEventFilterPrefix(allocName, allocID))
+		return fmt.Sprintf("Events for Allocation %s", allocEventFilterPrefix(info.AllocName, info.AllocID))
 	case AllocEventPage:
-		return fmt.Sprintf("Event for Allocation %s", allocEventFilterPrefix(allocName, allocID))
+		return fmt.Sprintf("Event for Allocation %s", allocEventFilterPrefix(info.AllocName, info.AllocID))
 	case AllEventsPage:
-		return fmt.Sprintf("All Events in Namespace %s (%s)", eventNamespace, formatEventTopics(eventTopics))
+		return fmt.Sprintf("All Events in Namespace %s (%s)", info.EventNamespace, formatEventTopics(info.EventTopics))
 	case AllEventPage:
 		return fmt.Sprintf("Event")
 	case JobTasksPage:
-		return fmt.Sprintf("Tasks for Job %s", style.Bold.Render(jobID))
+		return fmt.Sprintf("Tasks for Job %s", style.Bold.Render(info.JobID))
 	case ExecPage:
-		return fmt.Sprintf("Exec for Task %s", taskFilterPrefix(taskName, allocName))
+		return fmt.Sprintf("Exec for Task %s", taskFilterPrefix(info.TaskName, info.AllocName))
 	case AllocSpecPage:
-		return fmt.Sprintf("Spec for Allocation %s %s", style.Bold.Render(allocName), formatter.ShortAllocID(allocID))
+		return fmt.Sprintf("Spec for Allocation %s %s", style.Bold.Render(info.AllocName), formatter.ShortAllocID(info.AllocID))
 	case LogsPage:
-		return fmt.Sprintf("Logs for Task %s", taskFilterPrefix(taskName, allocName))
+		if info.MinLogLevel != AnyLevel {
+			return fmt.Sprintf("Logs for Task %s at %s level and above", taskFilterPrefix(info.TaskName, info.AllocName), style.Bold.Render(info.MinLogLevel.String()))
+		}
+		return fmt.Sprintf("Logs for Task %s", taskFilterPrefix(info.TaskName, info.AllocName))
 	case LoglinePage:
-		return fmt.Sprintf("Log Line for Task %s", taskFilterPrefix(taskName, allocName))
+		return fmt.Sprintf("Log Line for Task %s", taskFilterPrefix(info.TaskName, info.AllocName))
+	case LogSearchPage:
+		return fmt.Sprintf("Log Search for Task %s", taskFilterPrefix(info.TaskName, info.AllocName))
+	case AllocFilesPage:
+		return fmt.Sprintf("Files in Allocation %s at %s", allocEventFilterPrefix(info.AllocName, info.AllocID), style.Bold.Render(info.AllocFilePath))
+	case AllocFilePage:
+		return fmt.Sprintf("File %s in Allocation %s", style.Bold.Render(info.AllocFilePath), allocEventFilterPrefix(info.AllocName, info.AllocID))
+	case JobLogsPage:
+		return jobLogsFilterPrefix(info.JobID, info.TaskGroup)
+	case JobLogAllocsPage:
+		return fmt.Sprintf("Allocations in %s", jobLogsFilterPrefix(info.JobID, info.TaskGroup))
 	case StatsPage:
-		return fmt.Sprintf("Stats for Allocation %s", allocName)
+		return fmt.Sprintf("Stats for Allocation %s", info.AllocName)
+	case NodesPage:
+		return "Nodes"
+	case NodeTasksPage:
+		return fmt.Sprintf("Tasks on Node %s", style.Bold.Render(info.NodeName))
+	case NodeSpecPage:
+		return fmt.Sprintf("Spec for Node %s", style.Bold.Render(info.NodeName))
+	case JobDeploymentsPage:
+		return fmt.Sprintf("Deployments for Job %s", style.Bold.Render(info.JobID))
+	case AllDeploymentsPage:
+		return fmt.Sprintf("All Deployments in %s", namespaceFilterPrefix(info.Namespace))
+	case JobEvaluationsPage:
+		return fmt.Sprintf("Evaluations for Job %s", style.Bold.Render(info.JobID))
+	case JobEvaluationPage:
+		return fmt.Sprintf("Evaluation %s for Job %s", formatter.ShortAllocID(info.EvalID), style.Bold.Render(info.JobID))
+	case JobVersionsPage:
+		return jobVersionsFilterPrefix(info.JobID, info.MarkedJobVersion)
+	case JobVersionDiffPage:
+		return fmt.Sprintf("Diff for Job %s from Version %d to %d", style.Bold.Render(info.JobID), info.DiffFromVersion, info.DiffToVersion)
+	case SignalPage:
+		return fmt.Sprintf("Signal Task %s", taskFilterPrefix(info.TaskName, info.AllocName))
+	case NamespacesPage:
+		return fmt.Sprintf("Namespaces (current: %s)", namespaceFilterPrefix(info.Namespace))
+	case ProfilesPage:
+		return fmt.Sprintf("Profiles (current: %s)", style.Bold.Render(info.Profile))
+	case RegionsPage:
+		return fmt.Sprintf("Regions (current: %s)", regionFilterPrefix(info.Region))
+	case VariablesPage:
+		return fmt.Sprintf("Variables in %s", namespaceFilterPrefix(info.Namespace))
+	case VariablePage:
+		return fmt.Sprintf("Variable %s in Namespace %s", style.Bold.Render(info.VariablePath), info.VariableNamespace)
+	case ServicesPage:
+		return fmt.Sprintf("Services in %s", namespaceFilterPrefix(info.Namespace))
+	case ServicePage:
+		return fmt.Sprintf("Instances of Service %s", style.Bold.Render(info.ServiceName))
+	case VolumesPage:
+		return fmt.Sprintf("CSI Volumes in %s", namespaceFilterPrefix(info.Namespace))
+	case VolumePage:
+		return fmt.Sprintf("Tasks Claiming Volume %s", style.Bold.Render(info.VolumeID))
+	case PluginsPage:
+		return "CSI Plugins"
+	case HostVolumesPage:
+		return "Host Volumes"
 	default:
 		panic("page not found")
 	}
@@ -352,11 +800,16 @@ type LogsStream struct {
 }
 
 type PageLoadedMsg struct {
-	Page         Page
-	TableHeader  []string
-	AllPageRows  []page.Row
-	EventsStream EventsStream
-	LogsStream   LogsStream
+	Page          Page
+	TableHeader   []string
+	AllPageRows   []page.Row
+	EventsStream  EventsStream
+	LogsStream    LogsStream
+	LogSearch     LogSearch
+	JobLogsStream JobLogsStream
+	JSONLogs      JSONLogs
+	// PartialErr is why some rows couldn't be fetched, e.g. in one of several regions, shown with the rows that were
+	PartialErr error
 }
 
 type UpdatePageDataMsg struct {
@@ -374,6 +827,9 @@ func UpdatePageDataWithDelay(id int, p Page, d time.Duration) tea.Cmd {
 func getShortHelp(bindings []key.Binding) string {
 	var output string
 	for _, km := range bindings {
+		if !km.Enabled() {
+			continue
+		}
 		output += style.KeyHelpKey.Render(km.Help().Key) + " " + style.KeyHelpDescription.Render(km.Help().Desc) + "  "
 	}
 	output = strings.TrimSpace(output)
@@ -386,9 +842,10 @@ func changeKeyHelp(k *key.Binding, h string) {
 
 func GetPageKeyHelp(
 	currentPage Page,
-	filterFocused, filterApplied, saving, enteringInput, inPty, webSocketConnected bool,
+	filterFocused, filterApplied, saving, enteringInput, confirming, inPty, webSocketConnected bool,
 	logType LogType,
-	compact, inJobsMode bool,
+	compact bool,
+	mode Mode,
 ) string {
 	if compact {
 		changeKeyHelp(&keymap.KeyMap.Compact, "expand header")
@@ -397,7 +854,7 @@ func GetPageKeyHelp(
 		changeKeyHelp(&keymap.KeyMap.Compact, "compact")
 	}
 
-	if filterFocused || enteringInput {
+	if filterFocused || enteringInput || confirming {
 		keymap.KeyMap.Exit.SetHelp("ctrl+c", "exit")
 	} else {
 		keymap.KeyMap.Exit.SetHelp("q/ctrl+c", "exit")
@@ -405,6 +862,17 @@ func GetPageKeyHelp(
 
 	firstRow := []key.Binding{keymap.KeyMap.Exit}
 
+	if confirming {
+		changeKeyHelp(&keymap.KeyMap.Back, "cancel")
+		return getShortHelp(firstRow) + "\n" + getShortHelp([]key.Binding{keymap.KeyMap.Confirm, keymap.KeyMap.Deny, keymap.KeyMap.Back})
+	}
+
+	if enteringInput && currentPage != ExecPage {
+		changeKeyHelp(&keymap.KeyMap.Forward, "submit")
+		changeKeyHelp(&keymap.KeyMap.Back, "cancel")
+		return getShortHelp(firstRow) + "\n" + getShortHelp([]key.Binding{keymap.KeyMap.Back, keymap.KeyMap.Forward})
+	}
+
 	if !saving && !filterFocused {
 		firstRow = append(firstRow, keymap.KeyMap.Compact)
 		if currentPage.DoesReload() {
@@ -419,29 +887,74 @@ func GetPageKeyHelp(
 	var fourthRow []key.Binding
 	if nextPage := currentPage.Forward(); nextPage != currentPage {
 		changeKeyHelp(&keymap.KeyMap.Forward, currentPage.Forward().String())
+		if currentPage == AllocFilesPage {
+			// directories open in place
+			changeKeyHelp(&keymap.KeyMap.Forward, "open")
+		}
 		fourthRow = append(fourthRow, keymap.KeyMap.Forward)
 	}
 
 	if filterApplied {
 		changeKeyHelp(&keymap.KeyMap.Back, "remove filter")
 		fourthRow = append(fourthRow, keymap.KeyMap.Back)
-	} else if prevPage := currentPage.Backward(inJobsMode); prevPage != currentPage {
-		changeKeyHelp(&keymap.KeyMap.Back, fmt.Sprintf("%s", currentPage.Backward(inJobsMode).String()))
+	} else if currentPage == AllocFilesPage {
+		changeKeyHelp(&keymap.KeyMap.Back, "parent dir")
+		fourthRow = append(fourthRow, keymap.KeyMap.Back)
+	} else if prevPage := currentPage.Backward(mode); prevPage != currentPage {
+		changeKeyHelp(&keymap.KeyMap.Back, fmt.Sprintf("%s", currentPage.Backward(mode).String()))
 		fourthRow = append(fourthRow, keymap.KeyMap.Back)
 	}
 
-	if currentPage == JobsPage || currentPage.ShowsTasks() {
-		if currentPage == JobsPage {
-			fourthRow = append(fourthRow, keymap.KeyMap.TasksMode)
-		} else if currentPage == AllTasksPage {
+	if currentPage.IsModeRoot() {
+		if currentPage != JobsPage {
 			fourthRow = append(fourthRow, keymap.KeyMap.JobsMode)
 		}
+		if currentPage != AllTasksPage {
+			fourthRow = append(fourthRow, keymap.KeyMap.TasksMode)
+		}
+		if currentPage != NodesPage {
+			fourthRow = append(fourthRow, keymap.KeyMap.NodesMode)
+		}
+		if currentPage != ServicesPage {
+			fourthRow = append(fourthRow, keymap.KeyMap.ServicesMode)
+		}
+		if currentPage != VolumesPage {
+			fourthRow = append(fourthRow, keymap.KeyMap.VolumesMode)
+		}
+		if currentPage != NodesPage {
+			fourthRow = append(fourthRow, keymap.KeyMap.Namespaces)
+		}
+		fourthRow = append(fourthRow, keymap.KeyMap.Variables)
+		fourthRow = append(fourthRow, keymap.KeyMap.Regions)
+		fourthRow = append(fourthRow, keymap.KeyMap.Profiles)
+	}
+
+	if currentPage == JobsPage || currentPage == NodesPage || currentPage.ShowsTasks() {
 		fourthRow = append(fourthRow, keymap.KeyMap.Spec)
-	} else if currentPage == LogsPage {
-		if logType == StdOut {
+	} else if currentPage == LogsPage || currentPage == JobLogsPage {
+		if logType != StdOut {
+			fourthRow = append(fourthRow, keymap.KeyMap.StdOut)
+		}
+		if logType != StdErr {
 			fourthRow = append(fourthRow, keymap.KeyMap.StdErr)
+		}
+		if logType != StdOutAndStdErr {
+			fourthRow = append(fourthRow, keymap.KeyMap.StdOutAndStdErr)
+		}
+		fourthRow = append(fourthRow, keymap.KeyMap.PauseLogs)
+		fourthRow = append(fourthRow, keymap.KeyMap.JumpToTime)
+		fourthRow = append(fourthRow, keymap.KeyMap.LogsSince)
+		if currentPage == LogsPage {
+			fourthRow = append(fourthRow, keymap.KeyMap.SearchLogs)
+			fourthRow = append(fourthRow, keymap.KeyMap.JSONLogs)
+			fourthRow = append(fourthRow, keymap.KeyMap.MinLogLevel)
+			fourthRow = append(fourthRow, keymap.KeyMap.NextError)
+			fourthRow = append(fourthRow, keymap.KeyMap.PrevError)
+			fourthRow = append(fourthRow, keymap.KeyMap.ReceiveTimes)
+			fourthRow = append(fourthRow, keymap.KeyMap.RecordLogs)
+			fourthRow = append(fourthRow, keymap.KeyMap.Download)
 		} else {
-			fourthRow = append(fourthRow, keymap.KeyMap.StdOut)
+			fourthRow = append(fourthRow, keymap.KeyMap.JobLogAllocs)
 		}
 	}
 
@@ -449,12 +962,94 @@ func GetPageKeyHelp(
 		fourthRow = append(fourthRow, keymap.KeyMap.JobEvents)
 		fourthRow = append(fourthRow, keymap.KeyMap.AllEvents)
 		fourthRow = append(fourthRow, keymap.KeyMap.JobMeta)
+		fourthRow = append(fourthRow, keymap.KeyMap.JobDeployments)
+		fourthRow = append(fourthRow, keymap.KeyMap.AllDeployments)
+		fourthRow = append(fourthRow, keymap.KeyMap.JobEvaluations)
+		fourthRow = append(fourthRow, keymap.KeyMap.JobVersions)
+		fourthRow = append(fourthRow, keymap.KeyMap.StopJob)
+		fourthRow = append(fourthRow, keymap.KeyMap.PurgeJob)
+		fourthRow = append(fourthRow, keymap.KeyMap.StartJob)
+		fourthRow = append(fourthRow, keymap.KeyMap.RestartJob)
+		fourthRow = append(fourthRow, keymap.KeyMap.LaunchJob)
+		fourthRow = append(fourthRow, keymap.KeyMap.ExpandJob)
+	}
+
+	if currentPage == JobVersionsPage {
+		fourthRow = append(fourthRow, keymap.KeyMap.MarkVersion)
+		fourthRow = append(fourthRow, keymap.KeyMap.Revert)
+	}
+
+	if currentPage.ShowsDeployments() {
+		fourthRow = append(fourthRow, keymap.KeyMap.Promote)
+		fourthRow = append(fourthRow, keymap.KeyMap.Fail)
+		fourthRow = append(fourthRow, keymap.KeyMap.PauseResume)
 	}
 
 	if currentPage.ShowsTasks() {
 		fourthRow = append(fourthRow, keymap.KeyMap.AllocEvents)
 		fourthRow = append(fourthRow, keymap.KeyMap.Stats)
 		fourthRow = append(fourthRow, keymap.KeyMap.Exec)
+		fourthRow = append(fourthRow, keymap.KeyMap.AllocFiles)
+	}
+
+	if currentPage == AllocFilesPage || currentPage == AllocFilePage {
+		fourthRow = append(fourthRow, keymap.KeyMap.Download)
+	}
+
+	if currentPage.ShowsTasks() {
+		fourthRow = append(fourthRow, keymap.KeyMap.RestartTask)
+		fourthRow = append(fourthRow, keymap.KeyMap.RestartAlloc)
+		fourthRow = append(fourthRow, keymap.KeyMap.StopAlloc)
+		fourthRow = append(fourthRow, keymap.KeyMap.Signal)
+	}
+
+	if currentPage == JobTasksPage {
+		fourthRow = append(fourthRow, keymap.KeyMap.Scale)
+		fourthRow = append(fourthRow, keymap.KeyMap.TaskGroupLogs)
+		fourthRow = append(fourthRow, keymap.KeyMap.JobLogs)
+	}
+
+	if currentPage == SignalPage {
+		changeKeyHelp(&keymap.KeyMap.Forward, "send signal")
+		fourthRow = append([]key.Binding{keymap.KeyMap.Forward}, fourthRow...)
+	}
+
+	if currentPage == JobLogAllocsPage {
+		changeKeyHelp(&keymap.KeyMap.Forward, "show/hide logs")
+		fourthRow = append([]key.Binding{keymap.KeyMap.Forward}, fourthRow...)
+	}
+
+	if currentPage == NamespacesPage {
+		changeKeyHelp(&keymap.KeyMap.Forward, "switch namespace")
+		fourthRow = append([]key.Binding{keymap.KeyMap.Forward}, fourthRow...)
+	}
+
+	if currentPage == ProfilesPage {
+		changeKeyHelp(&keymap.KeyMap.Forward, "switch profile")
+		fourthRow = append([]key.Binding{keymap.KeyMap.Forward}, fourthRow...)
+	}
+
+	if currentPage == RegionsPage {
+		changeKeyHelp(&keymap.KeyMap.Forward, "switch region")
+		fourthRow = append([]key.Binding{keymap.KeyMap.Forward}, fourthRow...)
+	}
+
+	if currentPage == VolumesPage {
+		fourthRow = append(fourthRow, keymap.KeyMap.Plugins)
+		fourthRow = append(fourthRow, keymap.KeyMap.HostVolumes)
+	}
+
+	if currentPage == VariablesPage {
+		fourthRow = append(fourthRow, keymap.KeyMap.NewVariable)
+	}
+
+	if currentPage == VariablesPage || currentPage == VariablePage {
+		fourthRow = append(fourthRow, keymap.KeyMap.EditVariable)
+		fourthRow = append(fourthRow, keymap.KeyMap.DeleteVariable)
+	}
+
+	if currentPage == VariablePage {
+		fourthRow = append(fourthRow, keymap.KeyMap.RevealValues)
 	}
 
 	if currentPage == ExecPage {
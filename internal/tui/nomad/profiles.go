@@ -0,0 +1,43 @@
+package nomad
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/robinovitch61/wander/internal/tui/components/page"
+	"github.com/robinovitch61/wander/internal/tui/formatter"
+)
+
+// ProfileInfo is what the profiles table shows about a cluster profile
+type ProfileInfo struct {
+	Name, Address, Region, Namespace string
+}
+
+func FetchProfiles(profiles []ProfileInfo) tea.Cmd {
+	return func() tea.Msg {
+		tableHeader, allPageData := profilesAsTable(profiles)
+		return PageLoadedMsg{Page: ProfilesPage, TableHeader: tableHeader, AllPageRows: allPageData}
+	}
+}
+
+func profilesAsTable(profiles []ProfileInfo) ([]string, []page.Row) {
+	columns := []string{"Profile", "Address", "Region", "Namespace"}
+
+	var profileRows [][]string
+	var keys []string
+	for _, profile := range profiles {
+		region := profile.Region
+		if region == "" {
+			region = "-"
+		}
+		profileRows = append(profileRows, []string{profile.Name, profile.Address, region, profile.Namespace})
+		keys = append(keys, profile.Name)
+	}
+
+	table := formatter.GetRenderedTableAsString(columns, profileRows)
+
+	var rows []page.Row
+	for idx, row := range table.ContentRows {
+		rows = append(rows, page.Row{Key: keys[idx], Row: row})
+	}
+
+	return table.HeaderRows, rows
+}
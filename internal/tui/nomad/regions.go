@@ -0,0 +1,53 @@
+package nomad
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/hashicorp/nomad/api"
+	"github.com/robinovitch61/wander/internal/tui/components/page"
+	"github.com/robinovitch61/wander/internal/tui/formatter"
+	"github.com/robinovitch61/wander/internal/tui/message"
+	"sort"
+)
+
+// AllRegions lists jobs across every region in the federation. Other views stay scoped to a single region
+const AllRegions = "*"
+
+func FetchRegions(client api.Client) tea.Cmd {
+	return func() tea.Msg {
+		regions, err := client.Regions().List()
+		if err != nil {
+			return message.ErrMsg{Err: err}
+		}
+		sort.Strings(regions)
+
+		// the agent's region is used when no region is specified
+		agentRegion, _ := client.Agent().Region()
+
+		tableHeader, allPageData := regionsAsTable(regions, agentRegion)
+		return PageLoadedMsg{Page: RegionsPage, TableHeader: tableHeader, AllPageRows: allPageData}
+	}
+}
+
+func regionsAsTable(regions []string, agentRegion string) ([]string, []page.Row) {
+	columns := []string{"Region", "Description"}
+
+	regionRows := [][]string{{AllRegions, "Jobs in all regions"}}
+	keys := []string{AllRegions}
+	for _, region := range regions {
+		description := "-"
+		if region == agentRegion {
+			description = "Agent region"
+		}
+		regionRows = append(regionRows, []string{region, description})
+		keys = append(keys, region)
+	}
+
+	table := formatter.GetRenderedTableAsString(columns, regionRows)
+
+	var rows []page.Row
+	for idx, row := range table.ContentRows {
+		rows = append(rows, page.Row{Key: keys[idx], Row: row})
+	}
+
+	return table.HeaderRows, rows
+}
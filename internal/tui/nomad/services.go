@@ -0,0 +1,186 @@
+package nomad
+
+import (
+	"fmt"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/hashicorp/nomad/api"
+	"github.com/robinovitch61/wander/internal/tui/components/page"
+	"github.com/robinovitch61/wander/internal/tui/formatter"
+	"github.com/robinovitch61/wander/internal/tui/message"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ServiceAllocMsg is the allocation and task that registered a service, used to view its logs
+type ServiceAllocMsg struct {
+	Alloc    api.Allocation
+	TaskName string
+	Err      error
+}
+
+func FetchServices(client api.Client) tea.Cmd {
+	return func() tea.Msg {
+		namespacedServices, _, err := client.Services().List(nil)
+		if err != nil {
+			return message.ErrMsg{Err: err}
+		}
+
+		tableHeader, allPageData := servicesAsTable(namespacedServices)
+		return PageLoadedMsg{Page: ServicesPage, TableHeader: tableHeader, AllPageRows: allPageData}
+	}
+}
+
+type serviceRowEntry struct {
+	name, namespace string
+	tags            []string
+}
+
+func servicesAsTable(namespacedServices []*api.ServiceRegistrationListStub) ([]string, []page.Row) {
+	var entries []serviceRowEntry
+	for _, namespaced := range namespacedServices {
+		for _, service := range namespaced.Services {
+			entries = append(entries, serviceRowEntry{name: service.ServiceName, namespace: namespaced.Namespace, tags: service.Tags})
+		}
+	}
+	sort.Slice(entries, func(x, y int) bool {
+		if entries[x].name == entries[y].name {
+			return entries[x].namespace < entries[y].namespace
+		}
+		return entries[x].name < entries[y].name
+	})
+
+	columns := []string{"Service", "Namespace", "Tags"}
+	var serviceRows [][]string
+	var keys []string
+	for _, entry := range entries {
+		serviceRows = append(serviceRows, []string{entry.name, entry.namespace, formatTags(entry.tags)})
+		keys = append(keys, toServicesKey(entry.name, entry.namespace))
+	}
+
+	table := formatter.GetRenderedTableAsString(columns, serviceRows)
+
+	var rows []page.Row
+	for idx, row := range table.ContentRows {
+		rows = append(rows, page.Row{Key: keys[idx], Row: row})
+	}
+
+	return table.HeaderRows, rows
+}
+
+func formatTags(tags []string) string {
+	if len(tags) == 0 {
+		return "-"
+	}
+	return strings.Join(tags, ",")
+}
+
+func toServicesKey(serviceName, serviceNamespace string) string {
+	return serviceName + " " + serviceNamespace
+}
+
+func ServiceNameAndNamespaceFromKey(key string) (string, string) {
+	split := strings.Split(key, " ")
+	return split[0], split[1]
+}
+
+// FetchServiceRegistrations lists the instances of a service, one per registering allocation
+func FetchServiceRegistrations(client api.Client, serviceName, serviceNamespace string) tea.Cmd {
+	return func() tea.Msg {
+		registrations, _, err := client.Services().Get(serviceName, &api.QueryOptions{Namespace: serviceNamespace})
+		if err != nil {
+			return message.ErrMsg{Err: err}
+		}
+
+		sort.Slice(registrations, func(x, y int) bool {
+			if registrations[x].Address == registrations[y].Address {
+				return registrations[x].Port < registrations[y].Port
+			}
+			return registrations[x].Address < registrations[y].Address
+		})
+
+		tableHeader, allPageData := serviceRegistrationsAsTable(registrations)
+		return PageLoadedMsg{Page: ServicePage, TableHeader: tableHeader, AllPageRows: allPageData}
+	}
+}
+
+func serviceRegistrationsAsTable(registrations []*api.ServiceRegistration) ([]string, []page.Row) {
+	columns := []string{"Address", "Port", "Alloc ID", "Job", "Node ID", "Datacenter", "Tags"}
+
+	var registrationRows [][]string
+	var keys []string
+	for _, registration := range registrations {
+		registrationRows = append(registrationRows, []string{
+			registration.Address,
+			strconv.Itoa(registration.Port),
+			formatter.ShortAllocID(registration.AllocID),
+			registration.JobID,
+			formatter.ShortAllocID(registration.NodeID),
+			registration.Datacenter,
+			formatTags(registration.Tags),
+		})
+		keys = append(keys, toServiceRegistrationKey(registration))
+	}
+
+	table := formatter.GetRenderedTableAsString(columns, registrationRows)
+
+	var rows []page.Row
+	for idx, row := range table.ContentRows {
+		rows = append(rows, page.Row{Key: keys[idx], Row: row})
+	}
+
+	return table.HeaderRows, rows
+}
+
+func toServiceRegistrationKey(registration *api.ServiceRegistration) string {
+	return registration.AllocID + " " + registration.Namespace + " " + registration.ID
+}
+
+// ServiceRegistrationFromKey gets the allocation ID, namespace and registration ID of a service instance
+func ServiceRegistrationFromKey(key string) (string, string, string) {
+	split := strings.Split(key, " ")
+	return split[0], split[1], split[2]
+}
+
+// FetchServiceAlloc gets the allocation that registered a service, along with the task whose logs are most relevant
+func FetchServiceAlloc(client api.Client, allocID, allocNamespace, registrationID string) tea.Cmd {
+	return func() tea.Msg {
+		alloc, _, err := client.Allocations().Info(allocID, &api.QueryOptions{Namespace: allocNamespace})
+		if err != nil {
+			return ServiceAllocMsg{Err: err}
+		}
+		taskName := getServiceTaskName(alloc, registrationID)
+		if taskName == "" {
+			return ServiceAllocMsg{Err: fmt.Errorf("allocation %s has no tasks", formatter.ShortAllocID(allocID))}
+		}
+		return ServiceAllocMsg{Alloc: *alloc, TaskName: taskName}
+	}
+}
+
+// getServiceTaskName picks the task that registered the service, falling back to the first task in the allocation for
+// group services. Registration IDs look like _nomad-task-<alloc ID>-<task or group name>-<service>-<port label>
+func getServiceTaskName(alloc *api.Allocation, registrationID string) string {
+	var taskNames []string
+	for taskName := range alloc.TaskStates {
+		taskNames = append(taskNames, taskName)
+	}
+	sort.Strings(taskNames)
+
+	afterAllocID := strings.SplitN(registrationID, alloc.ID+"-", 2)
+	if len(afterAllocID) == 2 {
+		// the longest match, in case one task name is a prefix of another
+		var matched string
+		for _, taskName := range taskNames {
+			if strings.HasPrefix(afterAllocID[1], taskName+"-") && len(taskName) > len(matched) {
+				matched = taskName
+			}
+		}
+		if matched != "" {
+			return matched
+		}
+	}
+	if len(taskNames) > 0 {
+		return taskNames[0]
+	}
+	return ""
+}
@@ -0,0 +1,269 @@
+package nomad
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/hashicorp/nomad/api"
+	"github.com/robinovitch61/wander/internal/tui/components/page"
+	"github.com/robinovitch61/wander/internal/tui/formatter"
+	"github.com/robinovitch61/wander/internal/tui/message"
+	"os"
+	"os/exec"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const maskedVariableValue = "********"
+
+// VariableOpenedMsg is a variable written to File, ready to be edited. Variable has no ModifyIndex if it's new
+type VariableOpenedMsg struct {
+	Page     Page
+	Variable *api.Variable
+	File     string
+	Err      error
+}
+
+// VariableEditedMsg is the result of editing the File of a VariableOpenedMsg
+type VariableEditedMsg struct {
+	VariableOpenedMsg
+}
+
+// VariableDeletedMsg is the result of deleting a variable. Unlike other actions, the variable page can't be reloaded
+type VariableDeletedMsg struct {
+	Message string
+	Err     error
+}
+
+func FetchVariables(client api.Client) tea.Cmd {
+	return func() tea.Msg {
+		variables, _, err := client.Variables().List(nil)
+		if err != nil {
+			return message.ErrMsg{Err: err}
+		}
+
+		sort.Slice(variables, func(x, y int) bool {
+			if variables[x].Path == variables[y].Path {
+				return variables[x].Namespace < variables[y].Namespace
+			}
+			return variables[x].Path < variables[y].Path
+		})
+
+		tableHeader, allPageData := variablesAsTable(variables)
+		return PageLoadedMsg{Page: VariablesPage, TableHeader: tableHeader, AllPageRows: allPageData}
+	}
+}
+
+func variablesAsTable(variables []*api.VariableMetadata) ([]string, []page.Row) {
+	columns := []string{"Path", "Namespace", "Modified", "Since Modified"}
+
+	var variableRows [][]string
+	var keys []string
+	for _, variable := range variables {
+		variableRows = append(variableRows, []string{
+			variable.Path,
+			variable.Namespace,
+			formatter.FormatTimeNs(variable.ModifyTime),
+			formatter.FormatTimeNsSinceNow(variable.ModifyTime),
+		})
+		keys = append(keys, toVariablesKey(variable.Path, variable.Namespace, variable.ModifyIndex))
+	}
+
+	table := formatter.GetRenderedTableAsString(columns, variableRows)
+
+	var rows []page.Row
+	for idx, row := range table.ContentRows {
+		rows = append(rows, page.Row{Key: keys[idx], Row: row})
+	}
+
+	return table.HeaderRows, rows
+}
+
+func toVariablesKey(path, namespace string, modifyIndex uint64) string {
+	return path + keySeparator + namespace + keySeparator + strconv.FormatUint(modifyIndex, 10)
+}
+
+// VariableFromKey gets the path, namespace and modify index of the variable in a row of the variables page
+func VariableFromKey(key string) (string, string, uint64, error) {
+	split := strings.Split(key, keySeparator)
+	if len(split) != 3 {
+		return "", "", 0, fmt.Errorf("invalid variable key %q", key)
+	}
+	modifyIndex, err := strconv.ParseUint(split[2], 10, 64)
+	if err != nil {
+		return "", "", 0, err
+	}
+	return split[0], split[1], modifyIndex, nil
+}
+
+// FetchVariable shows the items of a variable, with their values masked unless revealValues is true
+func FetchVariable(client api.Client, path, namespace string, revealValues bool) tea.Cmd {
+	return func() tea.Msg {
+		variable, _, err := client.Variables().Read(path, &api.QueryOptions{Namespace: namespace})
+		if err != nil {
+			return message.ErrMsg{Err: err}
+		}
+
+		tableHeader, allPageData := variableItemsAsTable(variable, revealValues)
+		return PageLoadedMsg{Page: VariablePage, TableHeader: tableHeader, AllPageRows: allPageData}
+	}
+}
+
+func variableItemsAsTable(variable *api.Variable, revealValues bool) ([]string, []page.Row) {
+	columns := []string{"Key", "Value"}
+
+	var itemKeys []string
+	for k := range variable.Items {
+		itemKeys = append(itemKeys, k)
+	}
+	sort.Strings(itemKeys)
+
+	var itemRows [][]string
+	var keys []string
+	for _, k := range itemKeys {
+		value := maskedVariableValue
+		if revealValues {
+			value = variable.Items[k]
+		}
+		itemRows = append(itemRows, []string{k, value})
+		// every row includes the modify index, so the variable can be deleted from any of its rows
+		keys = append(keys, toVariablesKey(variable.Path, variable.Namespace, variable.ModifyIndex))
+	}
+
+	table := formatter.GetRenderedTableAsString(columns, itemRows)
+
+	var rows []page.Row
+	for idx, row := range table.ContentRows {
+		rows = append(rows, page.Row{Key: keys[idx], Row: row})
+	}
+
+	return table.HeaderRows, rows
+}
+
+// OpenVariable writes the items of the variable as json to a temporary file for editing. If isNew, the variable must
+// not exist yet
+func OpenVariable(client api.Client, path, namespace string, isNew bool, p Page) tea.Cmd {
+	return func() tea.Msg {
+		variable := api.NewVariable(path)
+		variable.Namespace = namespace
+		if !isNew {
+			existing, _, err := client.Variables().Read(path, &api.QueryOptions{Namespace: namespace})
+			if err != nil {
+				return VariableOpenedMsg{Page: p, Err: err}
+			}
+			variable = existing
+		} else {
+			variable.Items["key"] = "value"
+		}
+
+		content, err := json.MarshalIndent(variable.Items, "", "  ")
+		if err != nil {
+			return VariableOpenedMsg{Page: p, Err: err}
+		}
+		file, err := os.CreateTemp("", "wander-variable-*.json")
+		if err != nil {
+			return VariableOpenedMsg{Page: p, Err: err}
+		}
+		defer file.Close()
+		if _, err = file.Write(append(content, '\n')); err != nil {
+			return VariableOpenedMsg{Page: p, Err: err}
+		}
+		return VariableOpenedMsg{Page: p, Variable: variable, File: file.Name()}
+	}
+}
+
+func getEditor() []string {
+	for _, envVar := range []string{"VISUAL", "EDITOR"} {
+		if editor := strings.Fields(os.Getenv(envVar)); len(editor) > 0 {
+			return editor
+		}
+	}
+	return []string{"vi"}
+}
+
+// EditVariable hands the terminal over to $VISUAL or $EDITOR to edit the file of an opened variable
+func EditVariable(opened VariableOpenedMsg) tea.Cmd {
+	editor := getEditor()
+	c := exec.Command(editor[0], append(editor[1:], opened.File)...)
+	return tea.ExecProcess(c, func(err error) tea.Msg {
+		edited := VariableEditedMsg{opened}
+		if err != nil {
+			edited.Err = fmt.Errorf("editor %s failed: %w", editor[0], err)
+		}
+		return edited
+	})
+}
+
+// SaveVariable writes the edited items of a variable, failing if the variable changed since it was opened. The edited
+// file is kept if saving fails so no edits are lost
+func SaveVariable(client api.Client, edited VariableEditedMsg) tea.Cmd {
+	return func() tea.Msg {
+		p, variable := edited.Page, edited.Variable
+		keptErr := func(err error) tea.Msg {
+			return actionComplete(p, "", "", fmt.Errorf("%w (edits kept in %s)", err, edited.File))
+		}
+		if edited.Err != nil {
+			return keptErr(edited.Err)
+		}
+
+		content, err := os.ReadFile(edited.File)
+		if err != nil {
+			return actionComplete(p, "", "", err)
+		}
+		var items api.VariableItems
+		if err = json.Unmarshal(content, &items); err != nil {
+			return keptErr(fmt.Errorf("items must be a json object of strings: %w", err))
+		}
+		if len(items) == 0 {
+			return keptErr(fmt.Errorf("variable %s must have at least one item", variable.Path))
+		}
+
+		isNew := variable.ModifyIndex == 0
+		if !isNew && reflect.DeepEqual(items, variable.Items) {
+			_ = os.Remove(edited.File)
+			return actionComplete(p, fmt.Sprintf("No changes to variable %s", variable.Path), "", nil)
+		}
+
+		updated := variable.Copy()
+		updated.Items = items
+		opts := &api.WriteOptions{Namespace: variable.Namespace}
+		if isNew {
+			_, _, err = client.Variables().CheckedCreate(updated, opts)
+		} else {
+			_, _, err = client.Variables().CheckedUpdate(updated, opts)
+		}
+		var conflict api.ErrCASConflict
+		if errors.As(err, &conflict) {
+			if isNew {
+				return keptErr(fmt.Errorf("variable %s was created elsewhere while editing", variable.Path))
+			}
+			return keptErr(fmt.Errorf("variable %s was changed elsewhere while editing", variable.Path))
+		} else if err != nil {
+			return keptErr(err)
+		}
+
+		_ = os.Remove(edited.File)
+		action := "Updated"
+		if isNew {
+			action = "Created"
+		}
+		return actionComplete(p, fmt.Sprintf("%s variable %s", action, variable.Path), "", nil)
+	}
+}
+
+// DeleteVariable deletes the variable if it hasn't changed since modifyIndex
+func DeleteVariable(client api.Client, path, namespace string, modifyIndex uint64) tea.Cmd {
+	return func() tea.Msg {
+		_, err := client.Variables().CheckedDelete(path, modifyIndex, &api.WriteOptions{Namespace: namespace})
+		var conflict api.ErrCASConflict
+		if errors.As(err, &conflict) {
+			return VariableDeletedMsg{Err: fmt.Errorf("variable %s changed since it was loaded, reload and try again", path)}
+		} else if err != nil {
+			return VariableDeletedMsg{Err: err}
+		}
+		return VariableDeletedMsg{Message: fmt.Sprintf("Deleted variable %s", path)}
+	}
+}
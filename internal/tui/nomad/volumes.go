@@ -0,0 +1,282 @@
+package nomad
+
+import (
+	"encoding/json"
+	"fmt"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/hashicorp/nomad/api"
+	"github.com/robinovitch61/wander/internal/tui/components/page"
+	"github.com/robinovitch61/wander/internal/tui/formatter"
+	"github.com/robinovitch61/wander/internal/tui/message"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// maxConcurrentNodeInfos limits the requests for node details in flight at once, as a cluster may have many nodes
+const maxConcurrentNodeInfos = 16
+
+// volumeTaskColumns are the columns for the tasks of allocations claiming a volume, after the Claim column
+var volumeTaskColumns = []string{"Alloc ID", "Job", "Task Group", "Alloc Name", "Task Name", "State", "Node ID", "Uptime"}
+
+func FetchCSIVolumes(client api.Client) tea.Cmd {
+	return func() tea.Msg {
+		volumes, _, err := client.CSIVolumes().List(nil)
+		if err != nil {
+			return message.ErrMsg{Err: err}
+		}
+
+		sort.Slice(volumes, func(x, y int) bool {
+			if volumes[x].ID == volumes[y].ID {
+				return volumes[x].Namespace < volumes[y].Namespace
+			}
+			return volumes[x].ID < volumes[y].ID
+		})
+
+		tableHeader, allPageData := csiVolumesAsTable(volumes)
+		return PageLoadedMsg{Page: VolumesPage, TableHeader: tableHeader, AllPageRows: allPageData}
+	}
+}
+
+func csiVolumesAsTable(volumes []*api.CSIVolumeListStub) ([]string, []page.Row) {
+	columns := []string{"ID", "Name", "Namespace", "Plugin", "Schedulable", "Access Mode", "Readers", "Writers"}
+
+	var volumeRows [][]string
+	var keys []string
+	for _, volume := range volumes {
+		accessMode := string(volume.AccessMode)
+		if accessMode == "" {
+			// volumes with multiple capabilities don't have a single access mode until they're claimed
+			accessMode = "-"
+		}
+		volumeRows = append(volumeRows, []string{
+			volume.ID,
+			volume.Name,
+			volume.Namespace,
+			volume.PluginID,
+			strconv.FormatBool(volume.Schedulable),
+			accessMode,
+			strconv.Itoa(volume.CurrentReaders),
+			strconv.Itoa(volume.CurrentWriters),
+		})
+		keys = append(keys, toVolumesKey(volume.ID, volume.Namespace))
+	}
+
+	table := formatter.GetRenderedTableAsString(columns, volumeRows)
+
+	var rows []page.Row
+	for idx, row := range table.ContentRows {
+		rows = append(rows, page.Row{Key: keys[idx], Row: row})
+	}
+
+	return table.HeaderRows, rows
+}
+
+func toVolumesKey(volumeID, volumeNamespace string) string {
+	return volumeID + " " + volumeNamespace
+}
+
+func VolumeIDAndNamespaceFromKey(key string) (string, string) {
+	split := strings.Split(key, " ")
+	return split[0], split[1]
+}
+
+// FetchVolumeClaims lists the tasks of the allocations claiming a CSI volume
+func FetchVolumeClaims(client api.Client, volumeID, volumeNamespace string) tea.Cmd {
+	return func() tea.Msg {
+		volume, _, err := client.CSIVolumes().Info(volumeID, &api.QueryOptions{Namespace: volumeNamespace})
+		if err != nil {
+			return message.ErrMsg{Err: err}
+		}
+
+		var entries []volumeClaimRowEntry
+		for _, alloc := range volume.Allocations {
+			allocAsJSON, err := json.Marshal(alloc)
+			if err != nil {
+				return message.ErrMsg{Err: err}
+			}
+
+			claim := "read"
+			if _, isWriter := volume.WriteAllocs[alloc.ID]; isWriter {
+				claim = "write"
+			}
+			for taskName, task := range alloc.TaskStates {
+				entries = append(entries, volumeClaimRowEntry{
+					claim: claim,
+					taskRowEntry: taskRowEntry{
+						NodeID:               alloc.NodeID,
+						JobID:                alloc.JobID,
+						FullAllocationAsJSON: string(allocAsJSON),
+						ID:                   alloc.ID,
+						TaskGroup:            alloc.TaskGroup,
+						Name:                 alloc.Name,
+						TaskName:             taskName,
+						State:                task.State,
+						StartedAt:            task.StartedAt.UTC(),
+						FinishedAt:           task.FinishedAt.UTC(),
+					},
+				})
+			}
+		}
+
+		sort.Slice(entries, func(x, y int) bool {
+			if entries[x].Name == entries[y].Name {
+				if entries[x].TaskName == entries[y].TaskName {
+					return entries[x].ID < entries[y].ID
+				}
+				return entries[x].TaskName < entries[y].TaskName
+			}
+			return entries[x].Name < entries[y].Name
+		})
+
+		tableHeader, allPageData := volumeClaimsAsTable(entries)
+		return PageLoadedMsg{Page: VolumePage, TableHeader: tableHeader, AllPageRows: allPageData}
+	}
+}
+
+type volumeClaimRowEntry struct {
+	taskRowEntry
+	claim string
+}
+
+func volumeClaimsAsTable(entries []volumeClaimRowEntry) ([]string, []page.Row) {
+	columns := append([]string{"Claim"}, volumeTaskColumns...)
+
+	var claimRows [][]string
+	var keys []string
+	for _, entry := range entries {
+		claimRows = append(claimRows, append([]string{entry.claim}, getJobTaskRowFromColumns(entry.taskRowEntry, volumeTaskColumns)...))
+		keys = append(keys, toTaskKey(entry.State, entry.FullAllocationAsJSON, entry.TaskName))
+	}
+
+	table := formatter.GetRenderedTableAsString(columns, claimRows)
+
+	var rows []page.Row
+	for idx, row := range table.ContentRows {
+		rows = append(rows, page.Row{Key: keys[idx], Row: row})
+	}
+
+	return table.HeaderRows, rows
+}
+
+func FetchCSIPlugins(client api.Client) tea.Cmd {
+	return func() tea.Msg {
+		plugins, _, err := client.CSIPlugins().List(nil)
+		if err != nil {
+			return message.ErrMsg{Err: err}
+		}
+
+		sort.Slice(plugins, func(x, y int) bool {
+			return plugins[x].ID < plugins[y].ID
+		})
+
+		tableHeader, allPageData := csiPluginsAsTable(plugins)
+		return PageLoadedMsg{Page: PluginsPage, TableHeader: tableHeader, AllPageRows: allPageData}
+	}
+}
+
+func csiPluginsAsTable(plugins []*api.CSIPluginListStub) ([]string, []page.Row) {
+	columns := []string{"ID", "Provider", "Controllers Healthy", "Nodes Healthy"}
+
+	var pluginRows [][]string
+	var keys []string
+	for _, plugin := range plugins {
+		controllers := "-"
+		if plugin.ControllerRequired {
+			controllers = fmt.Sprintf("%d/%d", plugin.ControllersHealthy, plugin.ControllersExpected)
+		}
+		pluginRows = append(pluginRows, []string{
+			plugin.ID,
+			plugin.Provider,
+			controllers,
+			fmt.Sprintf("%d/%d", plugin.NodesHealthy, plugin.NodesExpected),
+		})
+		keys = append(keys, plugin.ID)
+	}
+
+	table := formatter.GetRenderedTableAsString(columns, pluginRows)
+
+	var rows []page.Row
+	for idx, row := range table.ContentRows {
+		rows = append(rows, page.Row{Key: keys[idx], Row: row})
+	}
+
+	return table.HeaderRows, rows
+}
+
+// FetchHostVolumes lists the host volumes of every node, which are only included in the full node details
+func FetchHostVolumes(client api.Client) tea.Cmd {
+	return func() tea.Msg {
+		nodeResults, _, err := client.Nodes().List(nil)
+		if err != nil {
+			return message.ErrMsg{Err: err}
+		}
+
+		// host volumes are only in the details of each node
+		nodes := make([]*api.Node, len(nodeResults))
+		errs := make([]error, len(nodeResults))
+		limit := make(chan struct{}, maxConcurrentNodeInfos)
+		var wg sync.WaitGroup
+		for i, nodeResult := range nodeResults {
+			wg.Add(1)
+			go func(i int, nodeID string) {
+				defer wg.Done()
+				limit <- struct{}{}
+				defer func() { <-limit }()
+				nodes[i], _, errs[i] = client.Nodes().Info(nodeID, nil)
+			}(i, nodeResult.ID)
+		}
+		wg.Wait()
+		for _, err := range errs {
+			if err != nil {
+				return message.ErrMsg{Err: err}
+			}
+		}
+
+		sort.Slice(nodes, func(x, y int) bool {
+			if nodes[x].Name == nodes[y].Name {
+				return nodes[x].ID < nodes[y].ID
+			}
+			return nodes[x].Name < nodes[y].Name
+		})
+
+		tableHeader, allPageData := hostVolumesAsTable(nodes)
+		return PageLoadedMsg{Page: HostVolumesPage, TableHeader: tableHeader, AllPageRows: allPageData}
+	}
+}
+
+func hostVolumesAsTable(nodes []*api.Node) ([]string, []page.Row) {
+	columns := []string{"Node", "Node ID", "Volume", "Path", "Read Only"}
+
+	var volumeRows [][]string
+	var keys []string
+	for _, node := range nodes {
+		var volumeNames []string
+		for name := range node.HostVolumes {
+			volumeNames = append(volumeNames, name)
+		}
+		sort.Strings(volumeNames)
+
+		for _, name := range volumeNames {
+			volume := node.HostVolumes[name]
+			volumeRows = append(volumeRows, []string{
+				node.Name,
+				formatter.ShortAllocID(node.ID),
+				name,
+				volume.Path,
+				strconv.FormatBool(volume.ReadOnly),
+			})
+			keys = append(keys, node.ID+" "+name)
+		}
+	}
+
+	table := formatter.GetRenderedTableAsString(columns, volumeRows)
+
+	var rows []page.Row
+	for idx, row := range table.ContentRows {
+		rows = append(rows, page.Row{Key: keys[idx], Row: row})
+	}
+
+	return table.HeaderRows, rows
+}
@@ -43,4 +43,23 @@ var (
 	StdErr                        = Regular.Copy().Foreground(red)
 	SuccessToast                  = Bold.Copy().PaddingLeft(1).Foreground(black).Background(darkgreen)
 	ErrorToast                    = Bold.Copy().PaddingLeft(1).Foreground(black).Background(darkred)
+	ConfirmationPrompt            = Bold.Copy().PaddingLeft(1).Foreground(black).Background(yellow)
+	DiffAdded                     = Regular.Copy().Foreground(darkgreen)
+	DiffDeleted                   = Regular.Copy().Foreground(red)
+	DiffEdited                    = Regular.Copy().Foreground(yellow)
+	LogLevelDebug                 = Regular.Copy().Foreground(grey)
+	LogLevelWarn                  = Regular.Copy().Foreground(yellow)
+	LogLevelError                 = Bold.Copy().Foreground(red)
 )
+
+// LogSources distinguishes the sources of interleaved logs, e.g. the allocations in the logs of a job
+var LogSources = []lipgloss.Style{
+	Regular.Copy().Foreground(blue),
+	Regular.Copy().Foreground(yellow),
+	Regular.Copy().Foreground(pink),
+	Regular.Copy().Foreground(greenblue),
+	Regular.Copy().Foreground(lipgloss.Color("2")),
+	Regular.Copy().Foreground(lipgloss.Color("4")),
+	Regular.Copy().Foreground(lipgloss.Color("5")),
+	Regular.Copy().Foreground(lipgloss.Color("3")),
+}